@@ -0,0 +1,148 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// badgeLabelColor is the background of the label half of a badge
+const badgeLabelColor = "#555"
+
+// maxBadgeLabelLength is the longest custom label a badge accepts, in characters
+const maxBadgeLabelLength = 100
+
+// badgeStyle describes how a badge is drawn
+type badgeStyle struct {
+	Height    int
+	Radius    int
+	FontSize  int
+	CharWidth float64 // Approximate average glyph width, in pixels
+	Padding   int     // Horizontal padding on each side of a text
+	Uppercase bool
+	Bold      bool
+	Gradient  string // Top and bottom overlay opacities, empty for a flat fill
+}
+
+// badgeStyles lists the supported badge styles, modeled after shields.io
+var badgeStyles = map[string]badgeStyle{
+	"flat":          {Height: 20, Radius: 3, FontSize: 11, CharWidth: 6.5, Padding: 6, Gradient: ".1,.1"},
+	"flat-square":   {Height: 20, Radius: 0, FontSize: 11, CharWidth: 6.5, Padding: 6},
+	"plastic":       {Height: 18, Radius: 4, FontSize: 11, CharWidth: 6.5, Padding: 6, Gradient: ".2,.1"},
+	"for-the-badge": {Height: 28, Radius: 0, FontSize: 10, CharWidth: 7.5, Padding: 12, Uppercase: true, Bold: true},
+}
+
+// renderBadge draws a two-part badge with a label on the left and a colored message on the right
+func renderBadge(style badgeStyle, label, message, color string) string {
+	if style.Uppercase {
+		label = strings.ToUpper(label)
+		message = strings.ToUpper(message)
+	}
+
+	textWidth := func(text string) int {
+		return int(float64(utf8.RuneCountInString(text))*style.CharWidth+0.5) + 2*style.Padding
+	}
+	labelWidth := textWidth(label)
+	messageWidth := textWidth(message)
+	width := labelWidth + messageWidth
+
+	fontWeight := "normal"
+	if style.Bold {
+		fontWeight = "bold"
+	}
+
+	var gradient, overlay string
+	if style.Gradient != "" {
+		opacities := strings.SplitN(style.Gradient, ",", 2)
+		gradient = fmt.Sprintf(`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#fff" stop-opacity="%s"/><stop offset="1" stop-opacity="%s"/></linearGradient>`,
+			opacities[0], opacities[1])
+		overlay = fmt.Sprintf(`<rect width="%d" height="%d" fill="url(#s)"/>`, width, style.Height)
+	}
+
+	label = html.EscapeString(label)
+	message = html.EscapeString(message)
+	textY := style.Height/2 + style.FontSize/3 + 1
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s: %s">`,
+		width, style.Height, label, message)
+	fmt.Fprintf(&b, `<title>%s: %s</title>`, label, message)
+	b.WriteString(gradient)
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="%d" rx="%d" fill="#fff"/></clipPath>`, width, style.Height, style.Radius)
+	b.WriteString(`<g clip-path="url(#r)">`)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`, labelWidth, style.Height, badgeLabelColor)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="%d" fill="%s"/>`, labelWidth, messageWidth, style.Height, color)
+	b.WriteString(overlay)
+	b.WriteString(`</g>`)
+	fmt.Fprintf(&b, `<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="%d" font-weight="%s">`,
+		style.FontSize, fontWeight)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, labelWidth/2, textY, label)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, labelWidth+messageWidth/2, textY, message)
+	b.WriteString(`</g></svg>`)
+
+	return b.String()
+}
+
+// writeBadge renders a badge for a status in the style requested by the style query parameter
+// (flat by default), with a short cache lifetime and an ETag so unchanged badges are not resent
+func writeBadge(c *gin.Context, defaultLabel string, status models.ServiceStatus) {
+	styleName := c.DefaultQuery("style", "flat")
+	style, ok := badgeStyles[styleName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid badge style"})
+		return
+	}
+
+	label := defaultLabel
+	if custom, ok := c.GetQuery("label"); ok {
+		if utf8.RuneCountInString(custom) > maxBadgeLabelLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Badge label is too long"})
+			return
+		}
+		label = custom
+	}
+
+	svg := renderBadge(style, label, status.Name, status.Color)
+
+	writeCached(c, "image/svg+xml; charset=utf-8", []byte(svg), 60)
+}
+
+// GetPublicBadge returns an SVG badge with the overall status of an organization's page
+func GetPublicBadge(c *gin.Context) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	services, _, err := loadPublicServices(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+		return
+	}
+
+	writeBadge(c, "status", models.WorstServiceStatus(aggregateServiceStatus(services)))
+}
+
+// GetPublicServiceBadge returns an SVG badge with the status of a publicly visible service
+func GetPublicServiceBadge(c *gin.Context) {
+	service, ok := findPublicService(c, c.Param("orgId"), c.Param("serviceId"))
+	if !ok {
+		return
+	}
+
+	writeBadge(c, service.Name, models.WorstServiceStatus(service.Status))
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// calendarLookback is how long ended maintenances stay in the calendar feed
+const calendarLookback = 30 * 24 * time.Hour
+
+// calendarTimeFormat is the UTC date-time format of iCalendar properties
+const calendarTimeFormat = "20060102T150405Z"
+
+// calendarWriter builds an iCalendar document with CRLF line endings and lines folded at
+// 75 octets, as RFC 5545 requires
+type calendarWriter struct {
+	b strings.Builder
+}
+
+// property writes a content line, folding it without splitting UTF-8 sequences
+func (w *calendarWriter) property(name, value string) {
+	line := name + ":" + value
+	for len(line) > 75 {
+		cut := 75
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.b.WriteString(line[:cut] + "\r\n")
+		// Continuation lines start with a space, which counts towards their length
+		line = " " + line[cut:]
+	}
+	w.b.WriteString(line + "\r\n")
+}
+
+// text writes a property with a TEXT value, escaping it
+func (w *calendarWriter) text(name, value string) {
+	w.property(name, escapeCalendarText(value))
+}
+
+// escapeCalendarText escapes a TEXT value as defined by RFC 5545
+func escapeCalendarText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// calendarEvent writes a maintenance as a VEVENT. The UID is derived from the maintenance ID so
+// calendar clients update the same event, and SEQUENCE tells them which revision is newest.
+// Deleted maintenances are sent as cancelled, one revision after their last one.
+func calendarEvent(w *calendarWriter, maintenance models.Maintenance, visibleServices []models.Service, pageURL string) {
+	status := "CONFIRMED"
+	sequence := maintenance.Sequence
+	modified := maintenance.UpdatedAt
+	if maintenance.Status == models.MaintenanceCancelled {
+		status = "CANCELLED"
+	}
+	if maintenance.DeletedAt.Valid {
+		status = "CANCELLED"
+		sequence++
+		modified = maintenance.DeletedAt.Time
+	}
+
+	// A window ended early is shown as it actually happened
+	end := maintenance.ScheduledEnd
+	if maintenance.Status == models.MaintenanceCompleted && maintenance.CompletedAt != nil && maintenance.CompletedAt.Before(end) {
+		end = *maintenance.CompletedAt
+	}
+
+	description := maintenance.Description
+	if len(visibleServices) > 0 {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Affected services: " + joinServiceNames(visibleServices)
+	}
+
+	w.property("BEGIN", "VEVENT")
+	w.property("UID", maintenance.ID+"@status-page")
+	w.property("DTSTAMP", modified.UTC().Format(calendarTimeFormat))
+	w.property("LAST-MODIFIED", modified.UTC().Format(calendarTimeFormat))
+	w.property("CREATED", maintenance.CreatedAt.UTC().Format(calendarTimeFormat))
+	w.property("DTSTART", maintenance.ScheduledStart.UTC().Format(calendarTimeFormat))
+	w.property("DTEND", end.UTC().Format(calendarTimeFormat))
+	w.property("SEQUENCE", strconv.Itoa(sequence))
+	w.property("STATUS", status)
+	w.text("SUMMARY", maintenance.Title)
+	if description != "" {
+		w.text("DESCRIPTION", description)
+	}
+	w.property("URL", pageURL+"#maintenance-"+maintenance.ID)
+	w.property("TRANSP", "TRANSPARENT")
+	w.property("END", "VEVENT")
+}
+
+// GetPublicMaintenanceCalendar returns an organization's maintenances as an iCalendar feed,
+// optionally limited to those affecting the service given by serviceId. Upcoming and recent
+// windows are included; cancelled and deleted ones are kept with STATUS:CANCELLED so calendars
+// remove them.
+func GetPublicMaintenanceCalendar(c *gin.Context) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	calendarName := org.Name + " Maintenances"
+	query := db.DB.Unscoped().Where("org_id = ? AND scheduled_end >= ?", org.ID, time.Now().Add(-calendarLookback))
+
+	if serviceID := c.Query("serviceId"); serviceID != "" {
+		service, ok := findPublicService(c, org.ID, serviceID)
+		if !ok {
+			return
+		}
+
+		calendarName = org.Name + " Maintenances - " + service.Name
+		query = query.Where("id IN (?)",
+			db.DB.Model(&models.MaintenanceService{}).Select("maintenance_id").Where("service_id = ?", service.ID))
+	}
+
+	var maintenances []models.Maintenance
+	if err := query.Order("scheduled_start ASC").Find(&maintenances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	pageURL := publicPageURL(c, org.ID)
+
+	var w calendarWriter
+	w.property("BEGIN", "VCALENDAR")
+	w.property("VERSION", "2.0")
+	w.property("PRODID", "-//Status Page//Maintenances//EN")
+	w.property("CALSCALE", "GREGORIAN")
+	w.property("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", calendarName)
+
+	for _, maintenance := range maintenances {
+		maintenanceServices, err := loadMaintenanceServices(maintenance.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+
+		visible := make([]models.Service, 0, len(maintenanceServices))
+		for _, service := range maintenanceServices {
+			if !service.Hidden {
+				visible = append(visible, service)
+			}
+		}
+
+		calendarEvent(&w, maintenance, visible, pageURL)
+	}
+
+	w.property("END", "VCALENDAR")
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(w.b.String()))
+}
@@ -0,0 +1,371 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// Custom domains are verified by publishing the verification value in a TXT record on the
+// verification host, e.g. _statuspage-verification.status.example.com
+const (
+	domainVerificationPrefix = "_statuspage-verification."
+	domainVerificationValue  = "status-page-verification="
+)
+
+// domainVerificationTimeout bounds the DNS lookup made while verifying a custom domain
+const domainVerificationTimeout = 10 * time.Second
+
+// hostnamePattern matches a lowercase fully qualified hostname without a port
+var hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`)
+
+// CustomDomainRequest represents the request for adding a custom domain
+type CustomDomainRequest struct {
+	Hostname string `json:"hostname" binding:"required"`
+}
+
+// CustomDomainResponse is a custom domain with the DNS record that verifies it
+type CustomDomainResponse struct {
+	ID                 string             `json:"id"`
+	Hostname           string             `json:"hostname"`
+	Verified           bool               `json:"verified"`
+	VerifiedAt         *time.Time         `json:"verifiedAt"`
+	VerificationRecord VerificationRecord `json:"verificationRecord"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+// VerificationRecord is the DNS TXT record proving control of a custom domain
+type VerificationRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// newCustomDomainResponse converts a custom domain for the settings API
+func newCustomDomainResponse(domain models.CustomDomain) CustomDomainResponse {
+	return CustomDomainResponse{
+		ID:         domain.ID,
+		Hostname:   domain.Hostname,
+		Verified:   domain.VerifiedAt != nil,
+		VerifiedAt: domain.VerifiedAt,
+		VerificationRecord: VerificationRecord{
+			Type:  "TXT",
+			Name:  domainVerificationPrefix + domain.Hostname,
+			Value: domainVerificationValue + domain.VerificationToken,
+		},
+		CreatedAt: domain.CreatedAt,
+	}
+}
+
+// normalizeHostname lowercases a hostname and strips any port and trailing dot
+func normalizeHostname(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// loadCustomDomains returns the custom domains of an organization in the order they were added
+func loadCustomDomains(orgID string) ([]CustomDomainResponse, error) {
+	var domains []models.CustomDomain
+	if err := db.DB.Where("org_id = ?", orgID).Order("created_at ASC").Find(&domains).Error; err != nil {
+		return nil, err
+	}
+
+	responses := make([]CustomDomainResponse, 0, len(domains))
+	for _, domain := range domains {
+		responses = append(responses, newCustomDomainResponse(domain))
+	}
+	return responses, nil
+}
+
+// findOrgCustomDomain returns a custom domain of an organization, writing the error response if
+// there is none
+func findOrgCustomDomain(c *gin.Context, orgID interface{}, domainID string) (models.CustomDomain, bool) {
+	var domain models.CustomDomain
+	if err := db.DB.Where("id = ? AND org_id = ?", domainID, orgID).First(&domain).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Custom domain not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve custom domain"})
+		}
+		return domain, false
+	}
+	return domain, true
+}
+
+// hostnameVerifiedElsewhere reports whether another organization already verified a hostname
+func hostnameVerifiedElsewhere(hostname, orgID string) (bool, error) {
+	var count int64
+	err := db.DB.Model(&models.CustomDomain{}).
+		Where("hostname = ? AND org_id != ? AND verified_at IS NOT NULL", hostname, orgID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetCustomDomains returns the custom domains of the user's organization
+func GetCustomDomains(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	domains, err := loadCustomDomains(orgID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve custom domains"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"domains": domains})
+}
+
+// AddCustomDomain registers a hostname for the organization's status page. The domain is only
+// served once verified; the response includes the TXT record to publish for that.
+func AddCustomDomain(c *gin.Context) {
+	var req CustomDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hostname := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(req.Hostname)), ".")
+	if len(hostname) > 253 || !hostnamePattern.MatchString(hostname) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hostname"})
+		return
+	}
+
+	orgID, _ := c.Get("org_id")
+
+	var existing int64
+	if err := db.DB.Model(&models.CustomDomain{}).
+		Where("org_id = ? AND hostname = ?", orgID, hostname).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check custom domains"})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Hostname is already registered"})
+		return
+	}
+
+	taken, err := hostnameVerifiedElsewhere(hostname, orgID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check custom domains"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "Hostname is in use by another organization"})
+		return
+	}
+
+	domain := models.CustomDomain{
+		ID:                utils.GenerateUUID(),
+		OrgID:             orgID.(string),
+		Hostname:          hostname,
+		VerificationToken: utils.GenerateToken(),
+	}
+
+	if err := db.DB.Create(&domain).Error; err != nil {
+		// The same hostname was added concurrently, caught by the (org_id, hostname) unique index
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Hostname is already registered"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add custom domain"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"domain": newCustomDomainResponse(domain)})
+}
+
+// VerifyCustomDomain checks the DNS TXT record of a custom domain and, when it holds the
+// domain's verification value, starts serving the organization's status page on it
+func VerifyCustomDomain(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	domain, ok := findOrgCustomDomain(c, orgID, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	if domain.VerifiedAt != nil {
+		c.JSON(http.StatusOK, gin.H{"domain": newCustomDomainResponse(domain)})
+		return
+	}
+
+	taken, err := hostnameVerifiedElsewhere(domain.Hostname, domain.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check custom domains"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "Hostname is in use by another organization"})
+		return
+	}
+
+	// A failed lookup means the record is missing or not yet propagated
+	ctx, cancel := context.WithTimeout(c.Request.Context(), domainVerificationTimeout)
+	records, _ := net.DefaultResolver.LookupTXT(ctx, domainVerificationPrefix+domain.Hostname)
+	cancel()
+	found := false
+	for _, record := range records {
+		if strings.TrimSpace(record) == domainVerificationValue+domain.VerificationToken {
+			found = true
+			break
+		}
+	}
+	if !found {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Verification record not found"})
+		return
+	}
+
+	now := time.Now()
+	domain.VerifiedAt = &now
+	if err := db.DB.Save(&domain).Error; err != nil {
+		// Another organization verified the hostname since it was checked above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Hostname is in use by another organization"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify custom domain"})
+		}
+		return
+	}
+
+	domainCache.forget(domain.Hostname)
+
+	c.JSON(http.StatusOK, gin.H{"domain": newCustomDomainResponse(domain)})
+}
+
+// DeleteCustomDomain removes a custom domain, which stops serving the status page on it
+func DeleteCustomDomain(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	domain, ok := findOrgCustomDomain(c, orgID, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	if err := db.DB.Delete(&domain).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete custom domain"})
+		return
+	}
+
+	domainCache.forget(domain.Hostname)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom domain deleted successfully"})
+}
+
+// customDomainCacheTTL is how long a hostname lookup is reused, including misses
+const customDomainCacheTTL = time.Minute
+
+// customDomainCacheLimit bounds the cache, which is keyed by client-supplied Host headers
+const customDomainCacheLimit = 10000
+
+type customDomainCacheEntry struct {
+	orgID   string // Empty when the hostname is not a verified custom domain
+	expires time.Time
+}
+
+// customDomainCache maps hostnames to the organizations serving a status page on them
+type customDomainCache struct {
+	mu      sync.RWMutex
+	entries map[string]customDomainCacheEntry
+}
+
+var domainCache = &customDomainCache{entries: make(map[string]customDomainCacheEntry)}
+
+// resolve returns the organization a hostname is a verified custom domain of, or an empty string
+func (cache *customDomainCache) resolve(hostname string) (string, error) {
+	cache.mu.RLock()
+	entry, ok := cache.entries[hostname]
+	cache.mu.RUnlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.orgID, nil
+	}
+
+	var domain models.CustomDomain
+	err := db.DB.Where("hostname = ? AND verified_at IS NOT NULL", hostname).First(&domain).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return "", err
+	}
+
+	cache.mu.Lock()
+	if len(cache.entries) >= customDomainCacheLimit {
+		cache.entries = make(map[string]customDomainCacheEntry)
+	}
+	cache.entries[hostname] = customDomainCacheEntry{orgID: domain.OrgID, expires: time.Now().Add(customDomainCacheTTL)}
+	cache.mu.Unlock()
+
+	return domain.OrgID, nil
+}
+
+// forget drops a hostname so changes to its domain take effect immediately
+func (cache *customDomainCache) forget(hostname string) {
+	cache.mu.Lock()
+	delete(cache.entries, hostname)
+	cache.mu.Unlock()
+}
+
+// customDomainKey is the request context key holding the organization of a custom domain request
+type customDomainKey struct{}
+
+// customDomainOrg returns the organization a request was addressed to through its custom domain
+func customDomainOrg(c *gin.Context) (string, bool) {
+	orgID, ok := c.Request.Context().Value(customDomainKey{}).(string)
+	return orgID, ok
+}
+
+// customDomainPath maps a path requested on an organization's custom domain to the regular
+// route: the root serves the status page, and public API and WebSocket paths may leave out the
+// organization ID. Only the organization's public pages are served on its domain, so any other
+// path, or one naming another organization, is rejected.
+func customDomainPath(path, orgID string) (string, bool) {
+	switch {
+	case path == "/" || path == "/status/"+orgID:
+		return "/status/" + orgID, true
+	case path == "/api/ws" || path == "/api/ws/"+orgID:
+		return "/api/ws/" + orgID, true
+	case strings.HasPrefix(path, "/api/public/"):
+		rest := strings.TrimPrefix(path, "/api/public/")
+		if rest == orgID || strings.HasPrefix(rest, orgID+"/") {
+			return path, true
+		}
+		return "/api/public/" + orgID + "/" + rest, true
+	}
+	return "", false
+}
+
+// CustomDomainRouter routes requests for verified custom domains to the public endpoints of
+// their organization, passing all other requests to next unchanged
+func CustomDomainRouter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgID, err := domainCache.resolve(normalizeHostname(r.Host))
+		if err != nil {
+			log.Printf("Failed to resolve custom domain %s: %v", r.Host, err)
+		}
+
+		if orgID != "" {
+			path, ok := customDomainPath(r.URL.Path, orgID)
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), customDomainKey{}, orgID))
+			r.URL.Path = path
+			r.URL.RawPath = ""
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
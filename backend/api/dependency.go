@@ -0,0 +1,216 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DependencyRequest represents the request for declaring a service dependency
+type DependencyRequest struct {
+	DependsOnID string `json:"dependsOnId" binding:"required"`
+}
+
+// applyServiceImpact fills in the computed impact of the given services, derived from the
+// status of every service they depend on directly or transitively
+func applyServiceImpact(orgID interface{}, targets []models.Service) error {
+	var dependencies []models.ServiceDependency
+	if err := db.DB.Where("org_id = ?", orgID).Find(&dependencies).Error; err != nil {
+		return err
+	}
+
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	var orgServices []models.Service
+	if err := db.DB.Where("org_id = ?", orgID).Find(&orgServices).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[string]models.Service, len(orgServices))
+	for _, service := range orgServices {
+		byID[service.ID] = service
+	}
+
+	graph := services.NewDependencyGraph(dependencies)
+	for i := range targets {
+		var impacting []models.Service
+		for _, upstreamID := range graph.Upstream(targets[i].ID) {
+			upstream, ok := byID[upstreamID]
+			if ok && upstream.Status != models.StatusOperational {
+				impacting = append(impacting, upstream)
+			}
+		}
+
+		if len(impacting) == 0 {
+			continue
+		}
+
+		targets[i].ImpactedStatus = aggregateServiceStatus(impacting)
+		for _, upstream := range impacting {
+			targets[i].ImpactedBy = append(targets[i].ImpactedBy, upstream.ID)
+		}
+	}
+
+	return nil
+}
+
+// GetServiceDependencies returns the services a service depends on and the services depending on it
+func GetServiceDependencies(c *gin.Context) {
+	serviceID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var service models.Service
+	if err := db.DB.Where("id = ? AND org_id = ?", serviceID, orgID).First(&service).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service"})
+		}
+		return
+	}
+
+	var dependsOnIDs []string
+	if err := db.DB.Model(&models.ServiceDependency{}).
+		Where("service_id = ?", service.ID).
+		Pluck("depends_on_id", &dependsOnIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve dependencies"})
+		return
+	}
+
+	var dependentIDs []string
+	if err := db.DB.Model(&models.ServiceDependency{}).
+		Where("depends_on_id = ?", service.ID).
+		Pluck("service_id", &dependentIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve dependents"})
+		return
+	}
+
+	dependsOn := []models.Service{}
+	if len(dependsOnIDs) > 0 {
+		if err := db.DB.Where("id IN ?", dependsOnIDs).Find(&dependsOn).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+	}
+
+	dependents := []models.Service{}
+	if len(dependentIDs) > 0 {
+		if err := db.DB.Where("id IN ?", dependentIDs).Find(&dependents).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dependsOn":  dependsOn,
+		"dependents": dependents,
+	})
+}
+
+// AddServiceDependency declares that a service depends on another service
+func AddServiceDependency(c *gin.Context) {
+	var req DependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	serviceID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	if serviceID == req.DependsOnID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A service cannot depend on itself"})
+		return
+	}
+
+	// Validate that both services exist and belong to the organization
+	var count int64
+	if err := db.DB.Model(&models.Service{}).
+		Where("id IN ? AND org_id = ?", []string{serviceID, req.DependsOnID}, orgID).
+		Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		return
+	}
+
+	if count != 2 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+		return
+	}
+
+	tx := db.DB.Begin()
+
+	// Locking the organization serializes dependency changes, so two concurrent requests
+	// cannot each pass the cycle check and together create a cycle
+	var org models.Organization
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", orgID).First(&org).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		return
+	}
+
+	var dependencies []models.ServiceDependency
+	if err := tx.Where("org_id = ?", orgID).Find(&dependencies).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve dependencies"})
+		return
+	}
+
+	graph := services.NewDependencyGraph(dependencies)
+	for _, dependsOnID := range graph[serviceID] {
+		if dependsOnID == req.DependsOnID {
+			tx.Rollback()
+			c.JSON(http.StatusConflict, gin.H{"error": "Dependency already exists"})
+			return
+		}
+	}
+
+	if graph.WouldCreateCycle(serviceID, req.DependsOnID) {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dependency would create a cycle"})
+		return
+	}
+
+	dependency := models.ServiceDependency{
+		ServiceID:   serviceID,
+		DependsOnID: req.DependsOnID,
+		OrgID:       orgID.(string),
+	}
+
+	if err := tx.Create(&dependency).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create dependency"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusCreated, gin.H{"dependency": dependency})
+}
+
+// RemoveServiceDependency removes a dependency between two services
+func RemoveServiceDependency(c *gin.Context) {
+	serviceID := c.Param("id")
+	dependsOnID := c.Param("dependsOnId")
+	orgID, _ := c.Get("org_id")
+
+	result := db.DB.Where("service_id = ? AND depends_on_id = ? AND org_id = ?", serviceID, dependsOnID, orgID).
+		Delete(&models.ServiceDependency{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete dependency"})
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dependency not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency deleted successfully"})
+}
@@ -0,0 +1,379 @@
+package api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// maxFeedItems is the number of most recently updated incidents and maintenances in a feed
+const maxFeedItems = 50
+
+// feedItem is an incident or maintenance as it appears in the RSS and Atom feeds
+type feedItem struct {
+	ID        string // Stable across feed builds, based on the incident or maintenance ID
+	Title     string
+	Link      string
+	Content   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// publicBaseURL returns the base URL of the public status pages, taken from PUBLIC_BASE_URL
+// or, when unset, from the request
+func publicBaseURL(c *gin.Context) string {
+	if baseURL := os.Getenv("PUBLIC_BASE_URL"); baseURL != "" {
+		return strings.TrimSuffix(baseURL, "/")
+	}
+
+	return requestBaseURL(c)
+}
+
+// requestBaseURL returns the scheme and host the request was made to
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// publicPageURL returns the URL of an organization's public status page, which is the root of
+// its custom domain when the request was made through one
+func publicPageURL(c *gin.Context, orgID string) string {
+	if _, ok := customDomainOrg(c); ok {
+		return requestBaseURL(c)
+	}
+	return publicBaseURL(c) + "/public/" + orgID
+}
+
+// loadFeedItems returns the most recently updated incidents and maintenances of an organization,
+// optionally limited to those affecting a service, newest first
+func loadFeedItems(orgID, serviceID, pageURL string) ([]feedItem, error) {
+	incidentQuery := db.DB.Where("org_id = ?", orgID)
+	maintenanceQuery := db.DB.Where("org_id = ?", orgID)
+	if serviceID != "" {
+		incidentQuery = incidentQuery.Where("id IN (?)",
+			db.DB.Model(&models.IncidentService{}).Select("incident_id").Where("service_id = ?", serviceID))
+		maintenanceQuery = maintenanceQuery.Where("id IN (?)",
+			db.DB.Model(&models.MaintenanceService{}).Select("maintenance_id").Where("service_id = ?", serviceID))
+	}
+
+	var incidents []models.Incident
+	if err := incidentQuery.Order("updated_at DESC").Limit(maxFeedItems).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	var maintenances []models.Maintenance
+	if err := maintenanceQuery.Order("updated_at DESC").Limit(maxFeedItems).Find(&maintenances).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]feedItem, 0, len(incidents)+len(maintenances))
+	for _, incident := range incidents {
+		item, err := incidentFeedItem(incident, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	for _, maintenance := range maintenances {
+		item, err := maintenanceFeedItem(maintenance, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Updated.After(items[j].Updated) })
+	if len(items) > maxFeedItems {
+		items = items[:maxFeedItems]
+	}
+
+	return items, nil
+}
+
+// incidentFeedItem describes an incident with its public timeline. The item is updated whenever
+// the incident or one of its updates changes.
+func incidentFeedItem(incident models.Incident, pageURL string) (feedItem, error) {
+	response, err := buildPublicIncident(incident)
+	if err != nil {
+		return feedItem{}, err
+	}
+
+	updated := incident.UpdatedAt
+	var latest []time.Time
+	if err := db.DB.Model(&models.IncidentUpdate{}).
+		Where("incident_id = ?", incident.ID).
+		Order("updated_at DESC").
+		Limit(1).
+		Pluck("updated_at", &latest).Error; err != nil {
+		return feedItem{}, err
+	}
+	if len(latest) > 0 && latest[0].After(updated) {
+		updated = latest[0]
+	}
+
+	var content strings.Builder
+	content.WriteString("Status: " + incident.Status + "\n")
+	if len(response.Services) > 0 {
+		content.WriteString("Affected services: " + joinServiceNames(response.Services) + "\n")
+	}
+	if incident.Description != "" {
+		content.WriteString("\n" + incident.Description + "\n")
+	}
+	for _, update := range response.Updates {
+		content.WriteString("\n" + update.CreatedAt + " - " + update.Status + "\n" + update.Message + "\n")
+	}
+
+	return feedItem{
+		ID:        "urn:uuid:" + incident.ID,
+		Title:     incident.Title,
+		Link:      pageURL + "#incident-" + response.Slug,
+		Content:   content.String(),
+		Published: incident.CreatedAt,
+		Updated:   updated,
+	}, nil
+}
+
+// maintenanceFeedItem describes a maintenance window with its publicly visible services
+func maintenanceFeedItem(maintenance models.Maintenance, pageURL string) (feedItem, error) {
+	maintenanceServices, err := loadMaintenanceServices(maintenance.ID)
+	if err != nil {
+		return feedItem{}, err
+	}
+
+	visible := make([]models.Service, 0, len(maintenanceServices))
+	for _, service := range maintenanceServices {
+		if !service.Hidden {
+			visible = append(visible, service)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString("Status: " + maintenance.Status + "\n")
+	content.WriteString("Scheduled: " + maintenance.ScheduledStart.UTC().Format(publicTimeFormat) +
+		" - " + maintenance.ScheduledEnd.UTC().Format(publicTimeFormat) + "\n")
+	if len(visible) > 0 {
+		content.WriteString("Affected services: " + joinServiceNames(visible) + "\n")
+	}
+	if maintenance.Description != "" {
+		content.WriteString("\n" + maintenance.Description + "\n")
+	}
+
+	return feedItem{
+		ID:        "urn:uuid:" + maintenance.ID,
+		Title:     "Maintenance: " + maintenance.Title,
+		Link:      pageURL + "#maintenance-" + maintenance.ID,
+		Content:   content.String(),
+		Published: maintenance.CreatedAt,
+		Updated:   maintenance.UpdatedAt,
+	}, nil
+}
+
+// joinServiceNames lists service names separated by commas
+func joinServiceNames(services []models.Service) string {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// feedSource holds what both feed formats are generated from
+type feedSource struct {
+	ID      string // Stable Atom feed ID, based on the organization or service ID
+	Title   string
+	PageURL string
+	FeedURL string
+	Items   []feedItem
+	Updated time.Time
+}
+
+// loadFeedSource resolves the organization and optional service filter of a feed request and
+// loads its items, writing the error response if it cannot
+func loadFeedSource(c *gin.Context) (feedSource, bool) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return feedSource{}, false
+	}
+
+	baseURL := publicBaseURL(c)
+	source := feedSource{
+		ID:      "urn:uuid:" + org.ID,
+		Title:   org.Name + " Status",
+		PageURL: publicPageURL(c, org.ID),
+		FeedURL: baseURL + c.Request.URL.RequestURI(),
+		Updated: org.CreatedAt,
+	}
+
+	serviceID := c.Query("serviceId")
+	if serviceID != "" {
+		service, ok := findPublicService(c, org.ID, serviceID)
+		if !ok {
+			return feedSource{}, false
+		}
+		source.ID = "urn:uuid:" + service.ID
+		source.Title = org.Name + " Status - " + service.Name
+	}
+
+	items, err := loadFeedItems(org.ID, serviceID, source.PageURL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve feed items"})
+		return feedSource{}, false
+	}
+
+	source.Items = items
+	if len(items) > 0 {
+		source.Updated = items[0].Updated
+	}
+
+	return source, true
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// GetPublicRSSFeed returns an RSS 2.0 feed of an organization's incidents and maintenances,
+// optionally limited to those affecting the service given by serviceId
+func GetPublicRSSFeed(c *gin.Context) {
+	source, ok := loadFeedSource(c)
+	if !ok {
+		return
+	}
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         source.Title,
+			Link:          source.PageURL,
+			Description:   "Incidents and maintenances for " + source.Title,
+			LastBuildDate: source.Updated.UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(source.Items)),
+		},
+	}
+
+	for _, item := range source.Items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: false, Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Description: item.Content,
+		})
+	}
+
+	writeFeed(c, "application/rss+xml; charset=utf-8", feed)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GetPublicAtomFeed returns an Atom feed of an organization's incidents and maintenances,
+// optionally limited to those affecting the service given by serviceId
+func GetPublicAtomFeed(c *gin.Context) {
+	source, ok := loadFeedSource(c)
+	if !ok {
+		return
+	}
+
+	feed := atomFeed{
+		ID:      source.ID,
+		Title:   source.Title,
+		Updated: source.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: source.PageURL, Rel: "alternate"},
+			{Href: source.FeedURL, Rel: "self"},
+		},
+		Entries: make([]atomEntry, 0, len(source.Items)),
+	}
+
+	for _, item := range source.Items {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        item.ID,
+			Title:     item.Title,
+			Link:      atomLink{Href: item.Link, Rel: "alternate"},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "text", Value: item.Content},
+		})
+	}
+
+	writeFeed(c, "application/atom+xml; charset=utf-8", feed)
+}
+
+// writeFeed writes a feed document as indented XML
+func writeFeed(c *gin.Context, contentType string, feed interface{}) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate feed"})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
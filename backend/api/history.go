@@ -0,0 +1,168 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// Pagination limits of the public incident history
+const (
+	defaultHistoryPerPage = 20
+	maxHistoryPerPage     = 100
+)
+
+// PublicHistoryIncident represents a past or ongoing incident in the public incident history
+type PublicHistoryIncident struct {
+	PublicIncidentResponse
+	ResolvedAt string `json:"resolvedAt,omitempty"`
+	Duration   *int64 `json:"duration"` // Seconds from creation to resolution, null while ongoing
+}
+
+// PublicHistoryMonth represents the incidents created during a calendar month (UTC)
+type PublicHistoryMonth struct {
+	Month     string                  `json:"month"` // YYYY-MM
+	Incidents []PublicHistoryIncident `json:"incidents"`
+}
+
+// parseHistoryTime parses a history range bound given as a date or an RFC 3339 timestamp.
+// A date-only upper bound includes the whole day.
+func parseHistoryTime(value string, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parsePositiveQuery returns a positive integer query parameter, or the fallback when it is absent
+func parsePositiveQuery(c *gin.Context, name string, fallback int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return fallback, true
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// GetPublicHistory returns an organization's incidents, newest first and grouped by month, for
+// the public status page. Results can be paginated (page, perPage) and filtered by creation
+// date (from, to) and by affected service (serviceId).
+func GetPublicHistory(c *gin.Context) {
+	orgID := c.Param("orgId")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID is required"})
+		return
+	}
+
+	page, ok := parsePositiveQuery(c, "page", 1)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+
+	perPage, ok := parsePositiveQuery(c, "perPage", defaultHistoryPerPage)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid perPage"})
+		return
+	}
+	if perPage > maxHistoryPerPage {
+		perPage = maxHistoryPerPage
+	}
+
+	query := db.DB.Model(&models.Incident{}).Where("org_id = ?", orgID)
+
+	if from := c.Query("from"); from != "" {
+		t, err := parseHistoryTime(from, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+		query = query.Where("created_at >= ?", t)
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := parseHistoryTime(to, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+		query = query.Where("created_at < ?", t)
+	}
+
+	if serviceID := c.Query("serviceId"); serviceID != "" {
+		// Internal-only services cannot be used to filter the public history
+		service, ok := findPublicService(c, orgID, serviceID)
+		if !ok {
+			return
+		}
+
+		query = query.Where("id IN (?)",
+			db.DB.Model(&models.IncidentService{}).Select("incident_id").Where("service_id = ?", service.ID))
+	}
+
+	// Share the filters between the count and the page query
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	var incidents []models.Incident
+	if err := query.Order("created_at DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&incidents).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	months := []PublicHistoryMonth{}
+	for _, incident := range incidents {
+		response, err := buildPublicIncident(incident)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+			return
+		}
+
+		entry := PublicHistoryIncident{PublicIncidentResponse: response}
+		if incident.ResolvedAt != nil {
+			entry.ResolvedAt = incident.ResolvedAt.UTC().Format(publicTimeFormat)
+			duration := int64(incident.ResolvedAt.Sub(incident.CreatedAt).Seconds())
+			entry.Duration = &duration
+		}
+
+		// Incidents are ordered newest first, so each month is contiguous
+		month := incident.CreatedAt.UTC().Format("2006-01")
+		if len(months) == 0 || months[len(months)-1].Month != month {
+			months = append(months, PublicHistoryMonth{Month: month, Incidents: []PublicHistoryIncident{}})
+		}
+		last := &months[len(months)-1]
+		last.Incidents = append(last.Incidents, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"months":  months,
+		"page":    page,
+		"perPage": perPage,
+		"total":   total,
+	})
+}
@@ -1,34 +1,449 @@
 package api
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/status_page/backend/db"
 	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/services"
 	"github.com/status_page/backend/utils"
 	"gorm.io/gorm"
 )
 
 // IncidentRequest represents the request for creating/updating an incident
 type IncidentRequest struct {
-	Title       string   `json:"title" binding:"required"`
+	Title       string   `json:"title"`
 	Description string   `json:"description"`
-	Status      string   `json:"status" binding:"required"`
-	ServiceIDs  []string `json:"serviceIds" binding:"required"`
+	Status      string   `json:"status"`
+	ServiceIDs  []string `json:"serviceIds"`
+	GroupIDs    []string `json:"groupIds"`
+
+	// Impact defaults to none; per-service impacts default to the incident impact
+	Impact             string            `json:"impact"`
+	ServiceImpacts     map[string]string `json:"serviceImpacts"`
+	AutoUpdateServices bool              `json:"autoUpdateServices"`
+
+	// On creation, fields left empty are taken from the template, whose placeholders are
+	// filled from Variables and the built-in variables
+	TemplateID string            `json:"templateId"`
+	Variables  map[string]string `json:"variables"`
 }
 
-// IncidentUpdateRequest represents the request for adding an update to an incident
+// IncidentUpdateRequest represents the request for adding an update to an incident,
+// optionally changing the incident status and the status of affected services
 type IncidentUpdateRequest struct {
-	Message string `json:"message" binding:"required"`
+	Message         string                 `json:"message"`
+	Status          string                 `json:"status"`
+	ServiceStatuses []ServiceStatusRequest `json:"serviceStatuses" binding:"dive"`
+
+	// A template message provides the message and status when they are left empty
+	TemplateMessageID string            `json:"templateMessageId"`
+	Variables         map[string]string `json:"variables"`
+}
+
+// ServiceStatusRequest represents a service status change carried by an incident update
+type ServiceStatusRequest struct {
+	ServiceID string `json:"serviceId" binding:"required"`
+	Status    string `json:"status" binding:"required"`
+}
+
+// ReopenIncidentRequest represents the request for reopening a resolved incident
+type ReopenIncidentRequest struct {
+	Message string `json:"message"`
 }
 
 // IncidentResponse represents an incident with its services and updates
 type IncidentResponse struct {
-	Incident models.Incident         `json:"incident"`
-	Services []models.Service        `json:"services"`
-	Updates  []models.IncidentUpdate `json:"updates"`
+	Incident       models.Incident         `json:"incident"`
+	Services       []models.Service        `json:"services"`
+	ServiceImpacts map[string]string       `json:"serviceImpacts"`
+	Updates        []models.IncidentUpdate `json:"updates"`
+	Metrics        IncidentMetrics         `json:"metrics"`
+
+	// Internal notes, only included when a single incident is retrieved
+	Notes []models.IncidentNote `json:"notes,omitempty"`
+}
+
+// IncidentMetrics represents how long an incident took to reach each lifecycle stage,
+// in seconds since it was created
+type IncidentMetrics struct {
+	TimeToIdentify *int64 `json:"timeToIdentify"`
+	TimeToMonitor  *int64 `json:"timeToMonitor"`
+	TimeToResolve  *int64 `json:"timeToResolve"`
+}
+
+// errInvalidTransition is returned when an incident cannot move to the requested status
+var errInvalidTransition = errors.New("invalid incident status transition")
+
+// transitionIncident moves an incident to a new status, enforcing the allowed transitions and
+// recording when each lifecycle stage is first reached
+func transitionIncident(incident *models.Incident, status string, now time.Time) error {
+	if incident.Status != "" && !models.CanTransitionIncident(incident.Status, status) {
+		return errInvalidTransition
+	}
+
+	incident.Status = status
+	switch status {
+	case models.IncidentIdentified:
+		if incident.IdentifiedAt == nil {
+			incident.IdentifiedAt = &now
+		}
+	case models.IncidentMonitoring:
+		if incident.MonitoringAt == nil {
+			incident.MonitoringAt = &now
+		}
+	case models.IncidentResolved:
+		if incident.ResolvedAt == nil {
+			incident.ResolvedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// incidentMetrics derives lifecycle durations from an incident's timestamps
+func incidentMetrics(incident models.Incident) IncidentMetrics {
+	since := func(at *time.Time) *int64 {
+		if at == nil {
+			return nil
+		}
+		seconds := int64(at.Sub(incident.CreatedAt).Seconds())
+		return &seconds
+	}
+
+	return IncidentMetrics{
+		TimeToIdentify: since(incident.IdentifiedAt),
+		TimeToMonitor:  since(incident.MonitoringAt),
+		TimeToResolve:  since(incident.ResolvedAt),
+	}
+}
+
+// errServicesNotFound is returned when a requested service or group does not belong to the organization
+var errServicesNotFound = errors.New("one or more services not found")
+
+// resolveAffectedServiceIDs expands the selected service groups into their member services
+// and merges them with the individually selected services, without duplicates
+func resolveAffectedServiceIDs(orgID interface{}, serviceIDs, groupIDs []string) ([]string, error) {
+	// Validate that all services exist and belong to the organization
+	if len(serviceIDs) > 0 {
+		var count int64
+		if err := db.DB.Model(&models.Service{}).
+			Where("id IN ? AND org_id = ?", serviceIDs, orgID).
+			Count(&count).Error; err != nil {
+			return nil, err
+		}
+
+		if int(count) != len(serviceIDs) {
+			return nil, errServicesNotFound
+		}
+	}
+
+	resolved := make([]string, 0, len(serviceIDs))
+	seen := make(map[string]bool)
+	for _, serviceID := range serviceIDs {
+		if !seen[serviceID] {
+			seen[serviceID] = true
+			resolved = append(resolved, serviceID)
+		}
+	}
+
+	if len(groupIDs) == 0 {
+		return resolved, nil
+	}
+
+	// Validate that all groups exist and belong to the organization
+	var count int64
+	if err := db.DB.Model(&models.ServiceGroup{}).
+		Where("id IN ? AND org_id = ?", groupIDs, orgID).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if int(count) != len(groupIDs) {
+		return nil, errServicesNotFound
+	}
+
+	var memberIDs []string
+	if err := db.DB.Model(&models.Service{}).
+		Where("group_id IN ? AND org_id = ?", groupIDs, orgID).
+		Order("name ASC").
+		Pluck("id", &memberIDs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, serviceID := range memberIDs {
+		if !seen[serviceID] {
+			seen[serviceID] = true
+			resolved = append(resolved, serviceID)
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveServiceImpacts validates the requested impact levels and returns the impact of every
+// affected service, defaulting to the incident impact
+func resolveServiceImpacts(impact string, serviceIDs []string, requested map[string]string) (map[string]string, bool) {
+	affected := make(map[string]bool, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		affected[serviceID] = true
+	}
+
+	for serviceID, serviceImpact := range requested {
+		if !affected[serviceID] || !models.IsValidImpact(serviceImpact) {
+			return nil, false
+		}
+	}
+
+	impacts := make(map[string]string, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		if serviceImpact, ok := requested[serviceID]; ok {
+			impacts[serviceID] = serviceImpact
+		} else {
+			impacts[serviceID] = impact
+		}
+	}
+
+	return impacts, true
+}
+
+// loadIncidentServiceImpacts returns the impact level of each service affected by an incident
+func loadIncidentServiceImpacts(incidentID string) (map[string]string, error) {
+	var links []models.IncidentService
+	if err := db.DB.Where("incident_id = ?", incidentID).Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	impacts := make(map[string]string, len(links))
+	for _, link := range links {
+		impacts[link.ServiceID] = link.Impact
+	}
+	return impacts, nil
+}
+
+// syncIncidentServices brings an incident's affected services in line with the given impacts,
+// remembering the status of newly affected services so it can be restored later. It returns
+// the associations that were removed.
+func syncIncidentServices(tx *gorm.DB, incidentID string, impacts map[string]string) ([]models.IncidentService, error) {
+	var existing []models.IncidentService
+	if err := tx.Where("incident_id = ?", incidentID).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	var removed []models.IncidentService
+	current := make(map[string]bool, len(existing))
+	for _, link := range existing {
+		impact, ok := impacts[link.ServiceID]
+		if !ok {
+			if err := tx.Where("incident_id = ? AND service_id = ?", incidentID, link.ServiceID).
+				Delete(&models.IncidentService{}).Error; err != nil {
+				return nil, err
+			}
+			removed = append(removed, link)
+			continue
+		}
+
+		current[link.ServiceID] = true
+		if link.Impact != impact {
+			if err := tx.Model(&models.IncidentService{}).
+				Where("incident_id = ? AND service_id = ?", incidentID, link.ServiceID).
+				Update("impact", impact).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for serviceID, impact := range impacts {
+		if current[serviceID] {
+			continue
+		}
+
+		var service models.Service
+		if err := tx.Where("id = ?", serviceID).First(&service).Error; err != nil {
+			return nil, err
+		}
+
+		previous, err := serviceBaselineStatus(tx, serviceID, incidentID, service.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		link := models.IncidentService{
+			IncidentID:     incidentID,
+			ServiceID:      serviceID,
+			Impact:         impact,
+			PreviousStatus: previous,
+		}
+		if err := tx.Create(&link).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return removed, nil
+}
+
+// openIncidentLink is a service's association with an open incident
+type openIncidentLink struct {
+	models.IncidentService
+	AutoUpdateServices bool
+}
+
+// openIncidentLinks returns the associations of a service with open incidents other than the
+// given one
+func openIncidentLinks(tx *gorm.DB, serviceID, incidentID string) ([]openIncidentLink, error) {
+	var links []openIncidentLink
+	err := tx.Table("incident_services").
+		Select("incident_services.*, incidents.auto_update_services").
+		Joins("JOIN incidents ON incidents.id = incident_services.incident_id").
+		Where("incident_services.service_id = ? AND incidents.id != ? AND incidents.status != ? AND incidents.deleted_at IS NULL",
+			serviceID, incidentID, models.IncidentResolved).
+		Scan(&links).Error
+	return links, err
+}
+
+// serviceBaselineStatus returns the status a service returns to once no open incident affects it.
+// A service already affected by another open incident has its current status set by that
+// incident, so the status recorded there is used instead.
+func serviceBaselineStatus(tx *gorm.DB, serviceID, incidentID, current string) (string, error) {
+	others, err := openIncidentLinks(tx, serviceID, incidentID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, other := range others {
+		if other.PreviousStatus != "" {
+			return other.PreviousStatus, nil
+		}
+	}
+	return current, nil
+}
+
+// applyIncidentImpact sets the status of every service affected by an incident from its impact
+// level, or from a worse impact of another open incident updating the same service
+func applyIncidentImpact(tx *gorm.DB, incidentID string) error {
+	var links []models.IncidentService
+	if err := tx.Where("incident_id = ?", incidentID).Find(&links).Error; err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		others, err := openIncidentLinks(tx, link.ServiceID, incidentID)
+		if err != nil {
+			return err
+		}
+
+		statuses := []string{models.ImpactServiceStatus(link.Impact)}
+		for _, other := range others {
+			if other.AutoUpdateServices {
+				statuses = append(statuses, models.ImpactServiceStatus(other.Impact))
+			}
+		}
+
+		if err := tx.Model(&models.Service{}).
+			Where("id = ?", link.ServiceID).
+			Update("status", models.WorstServiceStatus(statuses...).Name).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreIncidentServices returns the given services affected by an incident to their
+// pre-incident status. Services still affected by another open incident take the worst impact
+// among those incidents instead, and the pre-incident status is handed over to them.
+func restoreIncidentServices(tx *gorm.DB, incidentID string, links []models.IncidentService) error {
+	for _, link := range links {
+		others, err := openIncidentLinks(tx, link.ServiceID, incidentID)
+		if err != nil {
+			return err
+		}
+
+		if len(others) > 0 {
+			if link.PreviousStatus != "" {
+				incidentIDs := make([]string, 0, len(others))
+				for _, other := range others {
+					incidentIDs = append(incidentIDs, other.IncidentID)
+				}
+
+				if err := tx.Model(&models.IncidentService{}).
+					Where("service_id = ? AND incident_id IN ?", link.ServiceID, incidentIDs).
+					Update("previous_status", link.PreviousStatus).Error; err != nil {
+					return err
+				}
+			}
+
+			var statuses []string
+			for _, other := range others {
+				if other.AutoUpdateServices {
+					statuses = append(statuses, models.ImpactServiceStatus(other.Impact))
+				}
+			}
+
+			// Incidents that do not update services leave the status to be managed by hand
+			if len(statuses) == 0 {
+				continue
+			}
+
+			if err := tx.Model(&models.Service{}).
+				Where("id = ?", link.ServiceID).
+				Update("status", models.WorstServiceStatus(statuses...).Name).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Associations recorded before statuses were tracked fall back to Operational
+		previous := link.PreviousStatus
+		if previous == "" {
+			previous = models.StatusOperational
+		}
+
+		if err := tx.Model(&models.Service{}).
+			Where("id = ?", link.ServiceID).
+			Update("status", previous).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// captureIncidentServiceStatuses records the current status of every service affected by an
+// incident as the status to restore once it resolves, unless another open incident already
+// recorded one
+func captureIncidentServiceStatuses(tx *gorm.DB, incidentID string) error {
+	var links []models.IncidentService
+	if err := tx.Where("incident_id = ?", incidentID).Find(&links).Error; err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		var service models.Service
+		if err := tx.Where("id = ?", link.ServiceID).First(&service).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return err
+		}
+
+		previous, err := serviceBaselineStatus(tx, link.ServiceID, incidentID, service.Status)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.IncidentService{}).
+			Where("incident_id = ? AND service_id = ?", incidentID, link.ServiceID).
+			Update("previous_status", previous).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // GetIncidents returns all incidents for the user's organization
@@ -66,15 +481,23 @@ func GetIncidents(c *gin.Context) {
 
 		// Get updates for this incident
 		var updates []models.IncidentUpdate
-		if err := db.DB.Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
+		if err := db.DB.Preload("ServiceChanges").Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident updates"})
 			return
 		}
 
+		impacts, err := loadIncidentServiceImpacts(incident.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
+			return
+		}
+
 		responses = append(responses, IncidentResponse{
-			Incident: incident,
-			Services: incidentServices,
-			Updates:  updates,
+			Incident:       incident,
+			Services:       incidentServices,
+			ServiceImpacts: impacts,
+			Updates:        updates,
+			Metrics:        incidentMetrics(incident),
 		})
 	}
 
@@ -117,16 +540,31 @@ func GetIncident(c *gin.Context) {
 
 	// Get updates for this incident
 	var updates []models.IncidentUpdate
-	if err := db.DB.Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
+	if err := db.DB.Preload("ServiceChanges").Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident updates"})
 		return
 	}
 
+	impacts, err := loadIncidentServiceImpacts(incident.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
+		return
+	}
+
+	notes, err := loadIncidentNotes(incident.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident notes"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"incident": IncidentResponse{
-			Incident: incident,
-			Services: incidentServices,
-			Updates:  updates,
+			Incident:       incident,
+			Services:       incidentServices,
+			ServiceImpacts: impacts,
+			Updates:        updates,
+			Metrics:        incidentMetrics(incident),
+			Notes:          notes,
 		},
 	})
 }
@@ -140,47 +578,122 @@ func CreateIncident(c *gin.Context) {
 	}
 
 	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	// Fill in fields from the template, if any
+	var template *models.IncidentTemplate
+	if req.TemplateID != "" {
+		loaded, templateServiceIDs, err := loadIncidentTemplate(orgID, req.TemplateID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Incident template not found"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident template"})
+			}
+			return
+		}
 
-	// Validate status
-	validStatuses := map[string]bool{
-		"Investigating": true,
-		"Identified":    true,
-		"Monitoring":    true,
-		"Resolved":      true,
+		template = &loaded
+		if req.Title == "" {
+			req.Title = template.Title
+		}
+		if req.Description == "" {
+			req.Description = template.Description
+		}
+		if req.Status == "" {
+			req.Status = template.Status
+		}
+		if req.Impact == "" {
+			req.Impact = template.Impact
+		}
+		if len(req.ServiceIDs) == 0 && len(req.GroupIDs) == 0 {
+			req.ServiceIDs = templateServiceIDs
+		}
+	}
+
+	if req.Title == "" || req.Status == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title and status are required"})
+		return
 	}
 
-	if !validStatuses[req.Status] {
+	// Validate status
+	if !models.IsValidIncidentStatus(req.Status) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
 		return
 	}
 
-	// Validate that all services exist and belong to the organization
-	var count int64
-	if err := db.DB.Model(&models.Service{}).
-		Where("id IN ? AND org_id = ?", req.ServiceIDs, orgID).
-		Count(&count).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+	// Validate impact
+	if req.Impact == "" {
+		req.Impact = models.ImpactNone
+	}
+
+	if !models.IsValidImpact(req.Impact) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid impact value"})
+		return
+	}
+
+	// Resolve affected services, expanding any selected groups
+	serviceIDs, err := resolveAffectedServiceIDs(orgID, req.ServiceIDs, req.GroupIDs)
+	if err != nil {
+		if errors.Is(err, errServicesNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		}
+		return
+	}
+
+	if len(serviceIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one service or group is required"})
 		return
 	}
 
-	if int(count) != len(req.ServiceIDs) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+	impacts, ok := resolveServiceImpacts(req.Impact, serviceIDs, req.ServiceImpacts)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service impact"})
 		return
 	}
 
+	// Substitute the template placeholders
+	if template != nil {
+		variables, err := templateVariables(serviceIDs, "", req.Variables)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+
+		if req.Title, err = services.RenderTemplate(req.Title, variables); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid title: " + err.Error()})
+			return
+		}
+
+		variables["title"] = req.Title
+		if req.Description, err = services.RenderTemplate(req.Description, variables); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid description: " + err.Error()})
+			return
+		}
+	}
+
 	// Create incident
 	// --->>here<<--- Database transaction to create an incident and associate services
 	tx := db.DB.Begin()
 
 	incidentID := utils.GenerateUUID()
+	slug := utils.GenerateSlug()
 	incident := models.Incident{
 		ID:          incidentID,
+		Slug:        &slug,
 		Title:       req.Title,
 		Description: req.Description,
-		Status:      req.Status,
+		Impact:      req.Impact,
 		OrgID:       orgID.(string),
+
+		AutoUpdateServices: req.AutoUpdateServices,
 	}
 
+	// Any status is a valid starting point, but its lifecycle timestamp must be recorded
+	transitionIncident(&incident, req.Status, time.Now())
+
 	if err := tx.Create(&incident).Error; err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incident"})
@@ -188,15 +701,17 @@ func CreateIncident(c *gin.Context) {
 	}
 
 	// Associate services with the incident
-	for _, serviceID := range req.ServiceIDs {
-		incidentService := models.IncidentService{
-			IncidentID: incident.ID,
-			ServiceID:  serviceID,
-		}
+	if _, err := syncIncidentServices(tx, incident.ID, impacts); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with incident"})
+		return
+	}
 
-		if err := tx.Create(&incidentService).Error; err != nil {
+	// Set affected service statuses from their impact
+	if incident.AutoUpdateServices && incident.Status != models.IncidentResolved {
+		if err := applyIncidentImpact(tx, incident.ID); err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with incident"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service statuses"})
 			return
 		}
 	}
@@ -205,7 +720,9 @@ func CreateIncident(c *gin.Context) {
 	firstUpdate := models.IncidentUpdate{
 		ID:         utils.GenerateUUID(),
 		Message:    "Incident reported",
+		Status:     incident.Status,
 		IncidentID: incident.ID,
+		AuthorID:   userID.(string),
 	}
 
 	if err := tx.Create(&firstUpdate).Error; err != nil {
@@ -218,22 +735,24 @@ func CreateIncident(c *gin.Context) {
 
 	// Return the created incident with services and updates
 	var incidentServices []models.Service
-	if err := db.DB.Where("id IN ?", req.ServiceIDs).Find(&incidentServices).Error; err != nil {
+	if err := db.DB.Where("id IN ?", serviceIDs).Find(&incidentServices).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
 		return
 	}
 
 	var updates []models.IncidentUpdate
-	if err := db.DB.Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
+	if err := db.DB.Preload("ServiceChanges").Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident updates"})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"incident": IncidentResponse{
-			Incident: incident,
-			Services: incidentServices,
-			Updates:  updates,
+			Incident:       incident,
+			Services:       incidentServices,
+			ServiceImpacts: impacts,
+			Updates:        updates,
+			Metrics:        incidentMetrics(incident),
 		},
 	})
 }
@@ -249,30 +768,46 @@ func UpdateIncident(c *gin.Context) {
 	incidentID := c.Param("id")
 	orgID, _ := c.Get("org_id")
 
-	// Validate status
-	validStatuses := map[string]bool{
-		"Investigating": true,
-		"Identified":    true,
-		"Monitoring":    true,
-		"Resolved":      true,
+	if req.Title == "" || req.Status == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title and status are required"})
+		return
 	}
 
-	if !validStatuses[req.Status] {
+	// Validate status
+	if !models.IsValidIncidentStatus(req.Status) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
 		return
 	}
 
-	// Validate that all services exist and belong to the organization
-	var count int64
-	if err := db.DB.Model(&models.Service{}).
-		Where("id IN ? AND org_id = ?", req.ServiceIDs, orgID).
-		Count(&count).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+	// Validate impact
+	if req.Impact == "" {
+		req.Impact = models.ImpactNone
+	}
+
+	if !models.IsValidImpact(req.Impact) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid impact value"})
 		return
 	}
 
-	if int(count) != len(req.ServiceIDs) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+	// Resolve affected services, expanding any selected groups
+	serviceIDs, err := resolveAffectedServiceIDs(orgID, req.ServiceIDs, req.GroupIDs)
+	if err != nil {
+		if errors.Is(err, errServicesNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		}
+		return
+	}
+
+	if len(serviceIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one service or group is required"})
+		return
+	}
+
+	impacts, ok := resolveServiceImpacts(req.Impact, serviceIDs, req.ServiceImpacts)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service impact"})
 		return
 	}
 
@@ -292,9 +827,17 @@ func UpdateIncident(c *gin.Context) {
 	}
 
 	// Update incident fields
+	wasResolved := incident.Status == models.IncidentResolved
 	incident.Title = req.Title
 	incident.Description = req.Description
-	incident.Status = req.Status
+	incident.Impact = req.Impact
+	incident.AutoUpdateServices = req.AutoUpdateServices
+
+	if err := transitionIncident(&incident, req.Status, time.Now()); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot change incident status from " + incident.Status + " to " + req.Status})
+		return
+	}
 
 	if err := tx.Save(&incident).Error; err != nil {
 		tx.Rollback()
@@ -302,52 +845,64 @@ func UpdateIncident(c *gin.Context) {
 		return
 	}
 
-	// Remove existing service associations
-	if err := tx.Where("incident_id = ?", incidentID).Delete(&models.IncidentService{}).Error; err != nil {
+	// Update service associations, keeping the pre-incident status of services still affected
+	removed, err := syncIncidentServices(tx, incident.ID, impacts)
+	if err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident services"})
 		return
 	}
 
-	// Create new service associations
-	for _, serviceID := range req.ServiceIDs {
-		incidentService := models.IncidentService{
-			IncidentID: incident.ID,
-			ServiceID:  serviceID,
+	// Resolving returns affected services to their pre-incident status; while open, services
+	// follow their impact if requested
+	var statusErr error
+	switch {
+	case incident.Status == models.IncidentResolved && !wasResolved:
+		// Services removed by this same request were affected until now and are restored too
+		var links []models.IncidentService
+		if statusErr = tx.Where("incident_id = ?", incident.ID).Find(&links).Error; statusErr == nil {
+			statusErr = restoreIncidentServices(tx, incident.ID, append(links, removed...))
 		}
-
-		if err := tx.Create(&incidentService).Error; err != nil {
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with incident"})
-			return
+	case incident.AutoUpdateServices && incident.Status != models.IncidentResolved:
+		if statusErr = restoreIncidentServices(tx, incident.ID, removed); statusErr == nil {
+			statusErr = applyIncidentImpact(tx, incident.ID)
 		}
 	}
 
+	if statusErr != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service statuses"})
+		return
+	}
+
 	tx.Commit()
 
 	// Get updated services and updates
 	var incidentServices []models.Service
-	if err := db.DB.Where("id IN ?", req.ServiceIDs).Find(&incidentServices).Error; err != nil {
+	if err := db.DB.Where("id IN ?", serviceIDs).Find(&incidentServices).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
 		return
 	}
 
 	var updates []models.IncidentUpdate
-	if err := db.DB.Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
+	if err := db.DB.Preload("ServiceChanges").Where("incident_id = ?", incident.ID).Find(&updates).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident updates"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"incident": IncidentResponse{
-			Incident: incident,
-			Services: incidentServices,
-			Updates:  updates,
+			Incident:       incident,
+			Services:       incidentServices,
+			ServiceImpacts: impacts,
+			Updates:        updates,
+			Metrics:        incidentMetrics(incident),
 		},
 	})
 }
 
-// AddIncidentUpdate adds an update to an incident
+// AddIncidentUpdate adds an update to an incident, applying any incident and service
+// status changes it carries in the same transaction
 func AddIncidentUpdate(c *gin.Context) {
 	var req IncidentUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -357,10 +912,34 @@ func AddIncidentUpdate(c *gin.Context) {
 
 	incidentID := c.Param("id")
 	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	if req.Message == "" && req.TemplateMessageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Message or template message is required"})
+		return
+	}
+
+	// Validate statuses
+	if req.Status != "" && !models.IsValidIncidentStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+		return
+	}
+
+	for _, change := range req.ServiceStatuses {
+		if !models.IsValidServiceStatus(change.Status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service status value"})
+			return
+		}
+	}
+
+	// --->>here<<--- Database transaction to add an update to an incident
+	now := time.Now()
+	tx := db.DB.Begin()
 
 	// Check if incident exists and belongs to organization
 	var incident models.Incident
-	if err := db.DB.Where("id = ? AND org_id = ?", incidentID, orgID).First(&incident).Error; err != nil {
+	if err := tx.Where("id = ? AND org_id = ?", incidentID, orgID).First(&incident).Error; err != nil {
+		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Incident not found"})
 		} else {
@@ -369,24 +948,244 @@ func AddIncidentUpdate(c *gin.Context) {
 		return
 	}
 
-	// Create update
-	// --->>here<<--- Database operation to add an update to an incident
+	// Fill in the message and status from the template message, if any
+	if req.TemplateMessageID != "" {
+		var templateMessage models.IncidentTemplateMessage
+		if err := tx.Joins("JOIN incident_templates ON incident_templates.id = incident_template_messages.template_id").
+			Where("incident_template_messages.id = ? AND incident_templates.org_id = ? AND incident_templates.deleted_at IS NULL", req.TemplateMessageID, orgID).
+			First(&templateMessage).Error; err != nil {
+			tx.Rollback()
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Template message not found"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve template message"})
+			}
+			return
+		}
+
+		if req.Message == "" {
+			req.Message = templateMessage.Message
+		}
+		if req.Status == "" {
+			req.Status = templateMessage.Status
+		}
+
+		var serviceIDs []string
+		if err := tx.Model(&models.IncidentService{}).
+			Where("incident_id = ?", incident.ID).
+			Pluck("service_id", &serviceIDs).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
+			return
+		}
+
+		variables, err := templateVariables(serviceIDs, incident.Title, req.Variables)
+		if err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+
+		if req.Message, err = services.RenderTemplate(req.Message, variables); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message: " + err.Error()})
+			return
+		}
+	}
+
+	// Apply the incident status change
+	if req.Status != "" && req.Status != incident.Status {
+		if err := transitionIncident(&incident, req.Status, now); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot change incident status from " + incident.Status + " to " + req.Status})
+			return
+		}
+
+		if err := tx.Save(&incident).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident"})
+			return
+		}
+
+		// Resolving returns affected services to their pre-incident status
+		if incident.Status == models.IncidentResolved {
+			var links []models.IncidentService
+			if err := tx.Where("incident_id = ?", incident.ID).Find(&links).Error; err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
+				return
+			}
+
+			if err := restoreIncidentServices(tx, incident.ID, links); err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore service statuses"})
+				return
+			}
+		}
+	}
+
 	update := models.IncidentUpdate{
 		ID:         utils.GenerateUUID(),
 		Message:    req.Message,
+		Status:     incident.Status,
 		IncidentID: incidentID,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		AuthorID:   userID.(string),
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 
-	if err := db.DB.Create(&update).Error; err != nil {
+	if err := tx.Create(&update).Error; err != nil {
+		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incident update"})
 		return
 	}
 
+	// Apply service status changes, limited to services affected by the incident
+	for _, change := range req.ServiceStatuses {
+		var count int64
+		if err := tx.Model(&models.IncidentService{}).
+			Where("incident_id = ? AND service_id = ?", incident.ID, change.ServiceID).
+			Count(&count).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+			return
+		}
+
+		if count == 0 {
+			tx.Rollback()
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Service is not affected by this incident"})
+			return
+		}
+
+		var service models.Service
+		if err := tx.Where("id = ? AND org_id = ?", change.ServiceID, orgID).First(&service).Error; err != nil {
+			tx.Rollback()
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service"})
+			}
+			return
+		}
+
+		serviceChange := models.IncidentUpdateServiceChange{
+			ID:               utils.GenerateUUID(),
+			IncidentUpdateID: update.ID,
+			ServiceID:        service.ID,
+			PreviousStatus:   service.Status,
+			Status:           change.Status,
+		}
+
+		if err := tx.Create(&serviceChange).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record service status change"})
+			return
+		}
+
+		if err := tx.Model(&service).Update("status", change.Status).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service status"})
+			return
+		}
+
+		update.ServiceChanges = append(update.ServiceChanges, serviceChange)
+	}
+
+	tx.Commit()
+
 	c.JSON(http.StatusCreated, gin.H{"update": update})
 }
 
+// ReopenIncident reopens a resolved incident, recording who reopened it in the incident
+// and in its timeline
+func ReopenIncident(c *gin.Context) {
+	var req ReopenIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	tx := db.DB.Begin()
+
+	var incident models.Incident
+	if err := tx.Where("id = ? AND org_id = ?", incidentID, orgID).First(&incident).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident"})
+		}
+		return
+	}
+
+	if incident.Status != models.IncidentResolved {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": "Only resolved incidents can be reopened"})
+		return
+	}
+
+	now := time.Now()
+	incident.Status = models.IncidentInvestigating
+	incident.ResolvedAt = nil
+	incident.ReopenedAt = &now
+	incident.ReopenedByID = userID.(string)
+	incident.ReopenCount++
+
+	if err := tx.Save(&incident).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reopen incident"})
+		return
+	}
+
+	// Affected services are restored to their current status once the incident resolves again
+	if err := captureIncidentServiceStatuses(tx, incident.ID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service statuses"})
+		return
+	}
+
+	if incident.AutoUpdateServices {
+		if err := applyIncidentImpact(tx, incident.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service statuses"})
+			return
+		}
+	}
+
+	message := req.Message
+	if message == "" {
+		message = "Incident reopened"
+	}
+
+	update := models.IncidentUpdate{
+		ID:         utils.GenerateUUID(),
+		Message:    message,
+		Status:     incident.Status,
+		IncidentID: incident.ID,
+		AuthorID:   userID.(string),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := tx.Create(&update).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incident update"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{
+		"incident": incident,
+		"update":   update,
+		"metrics":  incidentMetrics(incident),
+	})
+}
+
 // DeleteIncident deletes an incident
 func DeleteIncident(c *gin.Context) {
 	incidentID := c.Param("id")
@@ -406,6 +1205,24 @@ func DeleteIncident(c *gin.Context) {
 		return
 	}
 
+	// Delete service status changes recorded with incident updates
+	if err := tx.Where("incident_update_id IN (?)",
+		tx.Unscoped().Model(&models.IncidentUpdate{}).Select("id").Where("incident_id = ?", incidentID)).
+		Delete(&models.IncidentUpdateServiceChange{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident update service changes"})
+		return
+	}
+
+	// Delete revisions of incident update messages
+	if err := tx.Where("incident_update_id IN (?)",
+		tx.Unscoped().Model(&models.IncidentUpdate{}).Select("id").Where("incident_id = ?", incidentID)).
+		Delete(&models.IncidentUpdateRevision{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident update revisions"})
+		return
+	}
+
 	// Delete incident updates
 	if err := tx.Where("incident_id = ?", incidentID).Delete(&models.IncidentUpdate{}).Error; err != nil {
 		tx.Rollback()
@@ -413,6 +1230,36 @@ func DeleteIncident(c *gin.Context) {
 		return
 	}
 
+	// Delete internal notes
+	if err := tx.Where("incident_id = ?", incidentID).Delete(&models.IncidentNote{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident notes"})
+		return
+	}
+
+	// Delete the postmortem
+	if err := tx.Where("incident_id = ?", incidentID).Delete(&models.Postmortem{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete postmortem"})
+		return
+	}
+
+	// An open incident returns its services to their pre-incident status, as resolving it would
+	if incident.Status != models.IncidentResolved {
+		var links []models.IncidentService
+		if err := tx.Where("incident_id = ?", incidentID).Find(&links).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
+			return
+		}
+
+		if err := restoreIncidentServices(tx, incident.ID, links); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore service statuses"})
+			return
+		}
+	}
+
 	// Delete incident-service associations
 	if err := tx.Where("incident_id = ?", incidentID).Delete(&models.IncidentService{}).Error; err != nil {
 		tx.Rollback()
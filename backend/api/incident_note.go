@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// IncidentNoteRequest represents the request for adding an internal note to an incident
+type IncidentNoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// loadIncidentNotes returns the internal notes of an incident, oldest first
+func loadIncidentNotes(incidentID string) ([]models.IncidentNote, error) {
+	notes := []models.IncidentNote{}
+	if err := db.DB.Where("incident_id = ?", incidentID).
+		Order("created_at ASC").
+		Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetIncidentNotes returns the internal notes of an incident
+func GetIncidentNotes(c *gin.Context) {
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	incident, ok := findOrgIncident(c, db.DB, orgID, incidentID)
+	if !ok {
+		return
+	}
+
+	notes, err := loadIncidentNotes(incident.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident notes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notes": notes})
+}
+
+// AddIncidentNote adds an internal note to an incident. Notes are never shown on the public
+// page nor sent to WebSocket clients.
+func AddIncidentNote(c *gin.Context) {
+	var req IncidentNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+	email, _ := c.Get("email")
+
+	incident, ok := findOrgIncident(c, db.DB, orgID, incidentID)
+	if !ok {
+		return
+	}
+
+	note := models.IncidentNote{
+		ID:          utils.GenerateUUID(),
+		IncidentID:  incident.ID,
+		AuthorID:    userID.(string),
+		AuthorEmail: email.(string),
+		Body:        req.Body,
+	}
+
+	if err := db.DB.Create(&note).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incident note"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"note": note})
+}
+
+// DeleteIncidentNote deletes an internal note; only its author or an admin may delete it
+func DeleteIncidentNote(c *gin.Context) {
+	incidentID := c.Param("id")
+	noteID := c.Param("noteId")
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+
+	incident, ok := findOrgIncident(c, db.DB, orgID, incidentID)
+	if !ok {
+		return
+	}
+
+	var note models.IncidentNote
+	if err := db.DB.Where("id = ? AND incident_id = ?", noteID, incident.ID).First(&note).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident note not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident note"})
+		}
+		return
+	}
+
+	if note.AuthorID != userID && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author or an admin can delete this note"})
+		return
+	}
+
+	if err := db.DB.Delete(&note).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Incident note deleted successfully"})
+}
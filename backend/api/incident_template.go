@@ -0,0 +1,357 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/services"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// IncidentTemplateRequest represents the request for creating/updating an incident template
+type IncidentTemplateRequest struct {
+	Name        string                           `json:"name" binding:"required"`
+	Title       string                           `json:"title" binding:"required"`
+	Description string                           `json:"description"`
+	Status      string                           `json:"status"`
+	Impact      string                           `json:"impact"`
+	ServiceIDs  []string                         `json:"serviceIds"`
+	GroupIDs    []string                         `json:"groupIds"`
+	Messages    []IncidentTemplateMessageRequest `json:"messages" binding:"dive"`
+}
+
+// IncidentTemplateMessageRequest represents a canned update message of an incident template
+type IncidentTemplateMessageRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Message string `json:"message" binding:"required"`
+	Status  string `json:"status"`
+}
+
+// IncidentTemplateResponse represents an incident template with its default services
+type IncidentTemplateResponse struct {
+	Template models.IncidentTemplate `json:"template"`
+	Services []models.Service        `json:"services"`
+}
+
+// templateVariables returns the variables available to incident templates: the names of the
+// affected services as {{service}} (and {{services}}) and the incident title as {{title}},
+// along with any caller-supplied variables
+func templateVariables(serviceIDs []string, title string, custom map[string]string) (map[string]string, error) {
+	variables := make(map[string]string, len(custom)+3)
+	for name, value := range custom {
+		variables[name] = value
+	}
+
+	var names []string
+	if len(serviceIDs) > 0 {
+		if err := db.DB.Model(&models.Service{}).
+			Where("id IN ?", serviceIDs).
+			Order("display_order ASC, name ASC").
+			Pluck("name", &names).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	variables["service"] = strings.Join(names, ", ")
+	variables["services"] = variables["service"]
+	if title != "" {
+		variables["title"] = title
+	}
+
+	return variables, nil
+}
+
+// loadIncidentTemplate returns an organization's incident template with its messages and default services
+func loadIncidentTemplate(orgID interface{}, templateID string) (models.IncidentTemplate, []string, error) {
+	var template models.IncidentTemplate
+	if err := db.DB.Preload("Messages", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("display_order ASC")
+	}).Where("id = ? AND org_id = ?", templateID, orgID).First(&template).Error; err != nil {
+		return template, nil, err
+	}
+
+	var serviceIDs []string
+	if err := db.DB.Model(&models.IncidentTemplateService{}).
+		Where("template_id = ? AND service_id IN (?)", template.ID, db.DB.Model(&models.Service{}).Select("id")).
+		Pluck("service_id", &serviceIDs).Error; err != nil {
+		return template, nil, err
+	}
+
+	return template, serviceIDs, nil
+}
+
+// bindIncidentTemplateRequest validates a template request and resolves its default services
+func bindIncidentTemplateRequest(c *gin.Context, orgID interface{}) (IncidentTemplateRequest, []string, bool) {
+	var req IncidentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return req, nil, false
+	}
+
+	if req.Status == "" {
+		req.Status = models.IncidentInvestigating
+	}
+	if req.Impact == "" {
+		req.Impact = models.ImpactNone
+	}
+
+	if !models.IsValidIncidentStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+		return req, nil, false
+	}
+
+	if !models.IsValidImpact(req.Impact) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid impact value"})
+		return req, nil, false
+	}
+
+	texts := []string{req.Title, req.Description}
+	for _, message := range req.Messages {
+		if message.Status != "" && !models.IsValidIncidentStatus(message.Status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message status value"})
+			return req, nil, false
+		}
+		texts = append(texts, message.Message)
+	}
+
+	for _, text := range texts {
+		if err := services.ValidateTemplate(text); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template: " + err.Error()})
+			return req, nil, false
+		}
+	}
+
+	serviceIDs, err := resolveAffectedServiceIDs(orgID, req.ServiceIDs, req.GroupIDs)
+	if err != nil {
+		if errors.Is(err, errServicesNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		}
+		return req, nil, false
+	}
+
+	return req, serviceIDs, true
+}
+
+// replaceIncidentTemplateContents replaces the default services and messages of a template
+func replaceIncidentTemplateContents(tx *gorm.DB, templateID string, serviceIDs []string, messages []IncidentTemplateMessageRequest) error {
+	if err := tx.Where("template_id = ?", templateID).Delete(&models.IncidentTemplateService{}).Error; err != nil {
+		return err
+	}
+
+	for _, serviceID := range serviceIDs {
+		link := models.IncidentTemplateService{
+			TemplateID: templateID,
+			ServiceID:  serviceID,
+		}
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("template_id = ?", templateID).Delete(&models.IncidentTemplateMessage{}).Error; err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		templateMessage := models.IncidentTemplateMessage{
+			ID:           utils.GenerateUUID(),
+			TemplateID:   templateID,
+			Name:         message.Name,
+			Message:      message.Message,
+			Status:       message.Status,
+			DisplayOrder: i,
+		}
+		if err := tx.Create(&templateMessage).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// respondWithIncidentTemplate reloads a template and writes it with its default services
+func respondWithIncidentTemplate(c *gin.Context, status int, orgID interface{}, templateID string) {
+	template, serviceIDs, err := loadIncidentTemplate(orgID, templateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident template"})
+		return
+	}
+
+	templateServices := []models.Service{}
+	if len(serviceIDs) > 0 {
+		if err := db.DB.Where("id IN ?", serviceIDs).Find(&templateServices).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+			return
+		}
+	}
+
+	c.JSON(status, gin.H{
+		"template": IncidentTemplateResponse{
+			Template: template,
+			Services: templateServices,
+		},
+	})
+}
+
+// GetIncidentTemplates returns all incident templates for the user's organization
+func GetIncidentTemplates(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	var templates []models.IncidentTemplate
+	if err := db.DB.Preload("Messages", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("display_order ASC")
+	}).Where("org_id = ?", orgID).Order("name ASC").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// GetIncidentTemplate returns a specific incident template
+func GetIncidentTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var count int64
+	if err := db.DB.Model(&models.IncidentTemplate{}).
+		Where("id = ? AND org_id = ?", templateID, orgID).
+		Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident template"})
+		return
+	}
+
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Incident template not found"})
+		return
+	}
+
+	respondWithIncidentTemplate(c, http.StatusOK, orgID, templateID)
+}
+
+// CreateIncidentTemplate creates a new incident template
+func CreateIncidentTemplate(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindIncidentTemplateRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	tx := db.DB.Begin()
+
+	template := models.IncidentTemplate{
+		ID:          utils.GenerateUUID(),
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		Impact:      req.Impact,
+		OrgID:       orgID.(string),
+	}
+
+	if err := tx.Create(&template).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incident template"})
+		return
+	}
+
+	if err := replaceIncidentTemplateContents(tx, template.ID, serviceIDs, req.Messages); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save incident template contents"})
+		return
+	}
+
+	tx.Commit()
+
+	respondWithIncidentTemplate(c, http.StatusCreated, orgID, template.ID)
+}
+
+// UpdateIncidentTemplate updates an existing incident template
+func UpdateIncidentTemplate(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindIncidentTemplateRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	templateID := c.Param("id")
+
+	tx := db.DB.Begin()
+
+	var template models.IncidentTemplate
+	if err := tx.Where("id = ? AND org_id = ?", templateID, orgID).First(&template).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident template not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident template"})
+		}
+		return
+	}
+
+	template.Name = req.Name
+	template.Title = req.Title
+	template.Description = req.Description
+	template.Status = req.Status
+	template.Impact = req.Impact
+
+	if err := tx.Save(&template).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident template"})
+		return
+	}
+
+	if err := replaceIncidentTemplateContents(tx, template.ID, serviceIDs, req.Messages); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save incident template contents"})
+		return
+	}
+
+	tx.Commit()
+
+	respondWithIncidentTemplate(c, http.StatusOK, orgID, template.ID)
+}
+
+// DeleteIncidentTemplate deletes an incident template
+func DeleteIncidentTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	var template models.IncidentTemplate
+	if err := tx.Where("id = ? AND org_id = ?", templateID, orgID).First(&template).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident template not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident template"})
+		}
+		return
+	}
+
+	if err := replaceIncidentTemplateContents(tx, template.ID, nil, nil); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident template contents"})
+		return
+	}
+
+	if err := tx.Delete(&template).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident template"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Incident template deleted successfully"})
+}
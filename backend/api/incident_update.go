@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// EditIncidentUpdateRequest represents the request for correcting the message of an incident update
+type EditIncidentUpdateRequest struct {
+	Message string `json:"message" binding:"required"`
+}
+
+// findIncidentUpdate returns an update of an organization's incident, writing the error response if it cannot
+func findIncidentUpdate(c *gin.Context, tx *gorm.DB, orgID interface{}, incidentID, updateID string) (models.IncidentUpdate, bool) {
+	var update models.IncidentUpdate
+
+	incident, ok := findOrgIncident(c, tx, orgID, incidentID)
+	if !ok {
+		return update, false
+	}
+
+	if err := tx.Where("id = ? AND incident_id = ?", updateID, incident.ID).First(&update).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident update not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident update"})
+		}
+		return update, false
+	}
+
+	return update, true
+}
+
+// GetIncidentUpdateRevisions returns the previous messages of an incident update, newest first
+func GetIncidentUpdateRevisions(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	update, ok := findIncidentUpdate(c, db.DB, orgID, c.Param("id"), c.Param("updateId"))
+	if !ok {
+		return
+	}
+
+	revisions := []models.IncidentUpdateRevision{}
+	if err := db.DB.Where("incident_update_id = ?", update.ID).
+		Order("created_at DESC").
+		Find(&revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident update revisions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// EditIncidentUpdate corrects the message of an incident update, keeping the previous message
+// as a revision. The status and service changes applied with the update are left as they are.
+func EditIncidentUpdate(c *gin.Context) {
+	var req EditIncidentUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	tx := db.DB.Begin()
+
+	update, ok := findIncidentUpdate(c, tx, orgID, c.Param("id"), c.Param("updateId"))
+	if !ok {
+		tx.Rollback()
+		return
+	}
+
+	if update.Message == req.Message {
+		tx.Rollback()
+		c.JSON(http.StatusOK, gin.H{"update": update})
+		return
+	}
+
+	revision := models.IncidentUpdateRevision{
+		ID:               utils.GenerateUUID(),
+		IncidentUpdateID: update.ID,
+		PreviousMessage:  update.Message,
+		EditorID:         userID.(string),
+	}
+
+	if err := tx.Create(&revision).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record incident update revision"})
+		return
+	}
+
+	now := time.Now()
+	update.Message = req.Message
+	update.EditedAt = &now
+
+	if err := tx.Save(&update).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident update"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"update": update})
+}
+
+// DeleteIncidentUpdate removes an update from an incident's timeline. The update is kept, with
+// its revisions, for the record.
+func DeleteIncidentUpdate(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	update, ok := findIncidentUpdate(c, db.DB, orgID, c.Param("id"), c.Param("updateId"))
+	if !ok {
+		return
+	}
+
+	if err := db.DB.Delete(&update).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete incident update"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Incident update deleted successfully"})
+}
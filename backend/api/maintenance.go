@@ -0,0 +1,443 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/services"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaintenanceRequest represents the request for creating/updating a scheduled maintenance
+type MaintenanceRequest struct {
+	Title          string    `json:"title" binding:"required"`
+	Description    string    `json:"description"`
+	ScheduledStart time.Time `json:"scheduledStart" binding:"required"`
+	ScheduledEnd   time.Time `json:"scheduledEnd" binding:"required"`
+	ServiceIDs     []string  `json:"serviceIds"`
+	GroupIDs       []string  `json:"groupIds"`
+}
+
+// MaintenanceResponse represents a maintenance with its affected services
+type MaintenanceResponse struct {
+	Maintenance models.Maintenance `json:"maintenance"`
+	Services    []models.Service   `json:"services"`
+}
+
+// loadMaintenanceServices returns the services affected by a maintenance
+func loadMaintenanceServices(maintenanceID string) ([]models.Service, error) {
+	var serviceIDs []string
+	if err := db.DB.Model(&models.MaintenanceService{}).
+		Where("maintenance_id = ?", maintenanceID).
+		Pluck("service_id", &serviceIDs).Error; err != nil {
+		return nil, err
+	}
+
+	services := []models.Service{}
+	if len(serviceIDs) > 0 {
+		if err := db.DB.Where("id IN ?", serviceIDs).
+			Order("display_order ASC, name ASC").
+			Find(&services).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return services, nil
+}
+
+// bindMaintenanceRequest validates a maintenance request and resolves its affected services
+func bindMaintenanceRequest(c *gin.Context, orgID interface{}) (MaintenanceRequest, []string, bool) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return req, nil, false
+	}
+
+	if !req.ScheduledEnd.After(req.ScheduledStart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scheduled end must be after scheduled start"})
+		return req, nil, false
+	}
+
+	serviceIDs, err := resolveAffectedServiceIDs(orgID, req.ServiceIDs, req.GroupIDs)
+	if err != nil {
+		if errors.Is(err, errServicesNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		}
+		return req, nil, false
+	}
+
+	if len(serviceIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one service or group is required"})
+		return req, nil, false
+	}
+
+	return req, serviceIDs, true
+}
+
+// GetMaintenances returns all maintenances for the user's organization
+func GetMaintenances(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	var maintenances []models.Maintenance
+	if err := db.DB.Where("org_id = ?", orgID).
+		Order("scheduled_start DESC").
+		Find(&maintenances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	responses := make([]MaintenanceResponse, 0, len(maintenances))
+	for _, maintenance := range maintenances {
+		services, err := loadMaintenanceServices(maintenance.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance services"})
+			return
+		}
+
+		responses = append(responses, MaintenanceResponse{
+			Maintenance: maintenance,
+			Services:    services,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"maintenances": responses})
+}
+
+// GetMaintenance returns a specific maintenance
+func GetMaintenance(c *gin.Context) {
+	maintenanceID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var maintenance models.Maintenance
+	if err := db.DB.Where("id = ? AND org_id = ?", maintenanceID, orgID).First(&maintenance).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance"})
+		}
+		return
+	}
+
+	services, err := loadMaintenanceServices(maintenance.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance services"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"maintenance": MaintenanceResponse{
+			Maintenance: maintenance,
+			Services:    services,
+		},
+	})
+}
+
+// CreateMaintenance schedules a new maintenance window
+func CreateMaintenance(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindMaintenanceRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	tx := db.DB.Begin()
+
+	maintenance := models.Maintenance{
+		ID:             utils.GenerateUUID(),
+		Title:          req.Title,
+		Description:    req.Description,
+		Status:         models.MaintenanceScheduled,
+		ScheduledStart: req.ScheduledStart.UTC(),
+		ScheduledEnd:   req.ScheduledEnd.UTC(),
+		OrgID:          orgID.(string),
+	}
+
+	if err := tx.Create(&maintenance).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create maintenance"})
+		return
+	}
+
+	// Associate services with the maintenance
+	for _, serviceID := range serviceIDs {
+		maintenanceService := models.MaintenanceService{
+			MaintenanceID: maintenance.ID,
+			ServiceID:     serviceID,
+		}
+
+		if err := tx.Create(&maintenanceService).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with maintenance"})
+			return
+		}
+	}
+
+	tx.Commit()
+
+	services, err := loadMaintenanceServices(maintenance.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance services"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"maintenance": MaintenanceResponse{
+			Maintenance: maintenance,
+			Services:    services,
+		},
+	})
+}
+
+// UpdateMaintenance updates a maintenance that has not started yet
+func UpdateMaintenance(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindMaintenanceRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	maintenanceID := c.Param("id")
+
+	tx := db.DB.Begin()
+
+	// Locked so the scheduler cannot start or complete the window meanwhile
+	var maintenance models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND org_id = ?", maintenanceID, orgID).
+		First(&maintenance).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance"})
+		}
+		return
+	}
+
+	if maintenance.Status != models.MaintenanceScheduled {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": "Only scheduled maintenances can be edited"})
+		return
+	}
+
+	maintenance.Title = req.Title
+	maintenance.Description = req.Description
+	maintenance.ScheduledStart = req.ScheduledStart.UTC()
+	maintenance.ScheduledEnd = req.ScheduledEnd.UTC()
+	maintenance.Sequence++
+
+	// An occurrence edited on its own no longer follows changes to its series
+	if maintenance.SeriesID != nil {
+		maintenance.Detached = true
+	}
+
+	if err := tx.Save(&maintenance).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance"})
+		return
+	}
+
+	// Replace service associations
+	if err := tx.Where("maintenance_id = ?", maintenance.ID).Delete(&models.MaintenanceService{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance services"})
+		return
+	}
+
+	for _, serviceID := range serviceIDs {
+		maintenanceService := models.MaintenanceService{
+			MaintenanceID: maintenance.ID,
+			ServiceID:     serviceID,
+		}
+
+		if err := tx.Create(&maintenanceService).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with maintenance"})
+			return
+		}
+	}
+
+	tx.Commit()
+
+	services, err := loadMaintenanceServices(maintenance.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance services"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"maintenance": MaintenanceResponse{
+			Maintenance: maintenance,
+			Services:    services,
+		},
+	})
+}
+
+// endMaintenance closes a maintenance in the given state early with the given final status
+func endMaintenance(c *gin.Context, from, status, conflictMessage string) {
+	maintenanceID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	// Locked so the scheduler cannot start or complete the window meanwhile
+	var maintenance models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND org_id = ?", maintenanceID, orgID).
+		First(&maintenance).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance"})
+		}
+		return
+	}
+
+	if maintenance.Status != from {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": conflictMessage})
+		return
+	}
+
+	// Ending a window early or cancelling it changes its calendar event
+	maintenance.Sequence++
+
+	if err := services.EndMaintenance(tx, &maintenance, status, time.Now()); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"maintenance": maintenance})
+}
+
+// CancelMaintenance cancels a maintenance that has not started yet
+func CancelMaintenance(c *gin.Context) {
+	endMaintenance(c, models.MaintenanceScheduled, models.MaintenanceCancelled, "Only scheduled maintenances can be cancelled")
+}
+
+// CompleteMaintenance ends an in-progress maintenance before its scheduled end
+func CompleteMaintenance(c *gin.Context) {
+	endMaintenance(c, models.MaintenanceInProgress, models.MaintenanceCompleted, "Only in-progress maintenances can be completed")
+}
+
+// DeleteMaintenance deletes a maintenance, restoring its services if it was in progress
+func DeleteMaintenance(c *gin.Context) {
+	maintenanceID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	// Locked so the scheduler cannot start or complete the window meanwhile
+	var maintenance models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND org_id = ?", maintenanceID, orgID).
+		First(&maintenance).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance"})
+		}
+		return
+	}
+
+	if maintenance.Status == models.MaintenanceInProgress {
+		if err := services.EndMaintenance(tx, &maintenance, models.MaintenanceCancelled, time.Now()); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore maintenance services"})
+			return
+		}
+	}
+
+	// The maintenance is soft-deleted with its services kept, so calendar feeds, including those
+	// of a single service, can report it as cancelled
+	if err := tx.Delete(&maintenance).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete maintenance"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Maintenance deleted successfully"})
+}
+
+// PublicMaintenanceResponse represents a maintenance with its services for the public API
+type PublicMaintenanceResponse struct {
+	ID             string           `json:"id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	Status         string           `json:"status"`
+	ScheduledStart string           `json:"scheduledStart"`
+	ScheduledEnd   string           `json:"scheduledEnd"`
+	Services       []models.Service `json:"services"`
+}
+
+// loadPublicMaintenances returns an organization's maintenances with the given statuses,
+// soonest first, with internal-only services left out
+func loadPublicMaintenances(orgID string, statuses ...string) ([]PublicMaintenanceResponse, error) {
+	var maintenances []models.Maintenance
+	if err := db.DB.Where("org_id = ? AND status IN ?", orgID, statuses).
+		Order("scheduled_start ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, err
+	}
+
+	responses := make([]PublicMaintenanceResponse, 0, len(maintenances))
+	for _, maintenance := range maintenances {
+		services, err := loadMaintenanceServices(maintenance.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		visible := make([]models.Service, 0, len(services))
+		for _, service := range services {
+			if !service.Hidden {
+				visible = append(visible, service)
+			}
+		}
+
+		responses = append(responses, PublicMaintenanceResponse{
+			ID:             maintenance.ID,
+			Title:          maintenance.Title,
+			Description:    maintenance.Description,
+			Status:         maintenance.Status,
+			ScheduledStart: maintenance.ScheduledStart.UTC().Format(publicTimeFormat),
+			ScheduledEnd:   maintenance.ScheduledEnd.UTC().Format(publicTimeFormat),
+			Services:       visible,
+		})
+	}
+
+	return responses, nil
+}
+
+// GetPublicMaintenances returns in-progress and upcoming maintenances for a public status page
+func GetPublicMaintenances(c *gin.Context) {
+	orgID := c.Param("orgId")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID is required"})
+		return
+	}
+
+	maintenances, err := loadPublicMaintenances(orgID, models.MaintenanceInProgress, models.MaintenanceScheduled)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"maintenances": maintenances})
+}
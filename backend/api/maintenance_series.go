@@ -0,0 +1,312 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/services"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// MaintenanceSeriesRequest represents the request for creating/updating a recurring maintenance
+type MaintenanceSeriesRequest struct {
+	Title           string    `json:"title" binding:"required"`
+	Description     string    `json:"description"`
+	RecurrenceRule  string    `json:"recurrenceRule" binding:"required"`
+	StartsAt        time.Time `json:"startsAt" binding:"required"`
+	DurationMinutes int       `json:"durationMinutes" binding:"required,min=1"`
+	ServiceIDs      []string  `json:"serviceIds"`
+	GroupIDs        []string  `json:"groupIds"`
+}
+
+// MaintenanceSeriesResponse represents a recurring maintenance with its services and occurrences
+type MaintenanceSeriesResponse struct {
+	Series      models.MaintenanceSeries `json:"series"`
+	Services    []models.Service         `json:"services"`
+	Occurrences []models.Maintenance     `json:"occurrences"`
+}
+
+// bindMaintenanceSeriesRequest validates a series request and resolves its affected services
+func bindMaintenanceSeriesRequest(c *gin.Context, orgID interface{}) (MaintenanceSeriesRequest, []string, bool) {
+	var req MaintenanceSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return req, nil, false
+	}
+
+	if _, err := services.ParseRecurrenceRule(req.RecurrenceRule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrence rule: " + err.Error()})
+		return req, nil, false
+	}
+
+	serviceIDs, err := resolveAffectedServiceIDs(orgID, req.ServiceIDs, req.GroupIDs)
+	if err != nil {
+		if errors.Is(err, errServicesNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more services not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate services"})
+		}
+		return req, nil, false
+	}
+
+	if len(serviceIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one service or group is required"})
+		return req, nil, false
+	}
+
+	return req, serviceIDs, true
+}
+
+// loadMaintenanceSeries returns a series with its services and materialized occurrences
+func loadMaintenanceSeries(series models.MaintenanceSeries) (MaintenanceSeriesResponse, error) {
+	var serviceIDs []string
+	if err := db.DB.Model(&models.MaintenanceSeriesService{}).
+		Where("series_id = ?", series.ID).
+		Pluck("service_id", &serviceIDs).Error; err != nil {
+		return MaintenanceSeriesResponse{}, err
+	}
+
+	seriesServices := []models.Service{}
+	if len(serviceIDs) > 0 {
+		if err := db.DB.Where("id IN ?", serviceIDs).
+			Order("display_order ASC, name ASC").
+			Find(&seriesServices).Error; err != nil {
+			return MaintenanceSeriesResponse{}, err
+		}
+	}
+
+	occurrences := []models.Maintenance{}
+	if err := db.DB.Where("series_id = ?", series.ID).
+		Order("scheduled_start ASC").
+		Find(&occurrences).Error; err != nil {
+		return MaintenanceSeriesResponse{}, err
+	}
+
+	return MaintenanceSeriesResponse{
+		Series:      series,
+		Services:    seriesServices,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// replaceMaintenanceSeriesServices replaces the services a series applies to
+func replaceMaintenanceSeriesServices(tx *gorm.DB, seriesID string, serviceIDs []string) error {
+	if err := tx.Where("series_id = ?", seriesID).Delete(&models.MaintenanceSeriesService{}).Error; err != nil {
+		return err
+	}
+
+	for _, serviceID := range serviceIDs {
+		link := models.MaintenanceSeriesService{
+			SeriesID:  seriesID,
+			ServiceID: serviceID,
+		}
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancelUpcomingOccurrences deletes the scheduled occurrences of a series that have not started
+// yet. They are soft-deleted so calendar feeds can report the cancellations.
+func cancelUpcomingOccurrences(tx *gorm.DB, seriesID string) error {
+	return tx.Where("series_id = ? AND status = ?", seriesID, models.MaintenanceScheduled).
+		Delete(&models.Maintenance{}).Error
+}
+
+// GetMaintenanceSeriesList returns all recurring maintenances for the user's organization
+func GetMaintenanceSeriesList(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	var seriesList []models.MaintenanceSeries
+	if err := db.DB.Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Find(&seriesList).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": seriesList})
+}
+
+// GetMaintenanceSeries returns a specific recurring maintenance with its occurrences
+func GetMaintenanceSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var series models.MaintenanceSeries
+	if err := db.DB.Where("id = ? AND org_id = ?", seriesID, orgID).First(&series).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance series not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series"})
+		}
+		return
+	}
+
+	response, err := loadMaintenanceSeries(series)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series occurrences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": response})
+}
+
+// CreateMaintenanceSeries creates a recurring maintenance and expands its upcoming occurrences
+func CreateMaintenanceSeries(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindMaintenanceSeriesRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	tx := db.DB.Begin()
+
+	series := models.MaintenanceSeries{
+		ID:              utils.GenerateUUID(),
+		Title:           req.Title,
+		Description:     req.Description,
+		RecurrenceRule:  req.RecurrenceRule,
+		StartsAt:        req.StartsAt.UTC(),
+		DurationMinutes: req.DurationMinutes,
+		OrgID:           orgID.(string),
+	}
+
+	if err := tx.Create(&series).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create maintenance series"})
+		return
+	}
+
+	if err := replaceMaintenanceSeriesServices(tx, series.ID, serviceIDs); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with maintenance series"})
+		return
+	}
+
+	if _, err := services.ExpandMaintenanceSeries(tx, &series, time.Now()); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to expand maintenance series"})
+		return
+	}
+
+	tx.Commit()
+
+	response, err := loadMaintenanceSeries(series)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series occurrences"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"series": response})
+}
+
+// UpdateMaintenanceSeries updates a recurring maintenance and regenerates its upcoming occurrences.
+// Occurrences that already started, were cancelled or were edited individually are left untouched.
+func UpdateMaintenanceSeries(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	req, serviceIDs, ok := bindMaintenanceSeriesRequest(c, orgID)
+	if !ok {
+		return
+	}
+
+	seriesID := c.Param("id")
+
+	tx := db.DB.Begin()
+
+	var series models.MaintenanceSeries
+	if err := tx.Where("id = ? AND org_id = ?", seriesID, orgID).First(&series).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance series not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series"})
+		}
+		return
+	}
+
+	series.Title = req.Title
+	series.Description = req.Description
+	series.RecurrenceRule = req.RecurrenceRule
+	series.StartsAt = req.StartsAt.UTC()
+	series.DurationMinutes = req.DurationMinutes
+
+	if err := tx.Save(&series).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance series"})
+		return
+	}
+
+	if err := replaceMaintenanceSeriesServices(tx, series.ID, serviceIDs); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to associate service with maintenance series"})
+		return
+	}
+
+	if err := services.RescheduleMaintenanceSeries(tx, &series, time.Now()); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance series occurrences"})
+		return
+	}
+
+	tx.Commit()
+
+	response, err := loadMaintenanceSeries(series)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series occurrences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": response})
+}
+
+// DeleteMaintenanceSeries deletes a recurring maintenance along with its upcoming occurrences.
+// Occurrences that already started or ended are kept for the record.
+func DeleteMaintenanceSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	var series models.MaintenanceSeries
+	if err := tx.Where("id = ? AND org_id = ?", seriesID, orgID).First(&series).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance series not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenance series"})
+		}
+		return
+	}
+
+	if err := cancelUpcomingOccurrences(tx, series.ID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete maintenance series occurrences"})
+		return
+	}
+
+	if err := tx.Where("series_id = ?", series.ID).Delete(&models.MaintenanceSeriesService{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete maintenance series services"})
+		return
+	}
+
+	if err := tx.Delete(&series).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete maintenance series"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Maintenance series deleted successfully"})
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// PostmortemRequest represents the request for writing an incident postmortem
+type PostmortemRequest struct {
+	Body            string `json:"body" binding:"required"`
+	PublishToPublic bool   `json:"publishToPublic"`
+}
+
+// PublicPostmortemResponse represents a published postmortem for the public API
+type PublicPostmortemResponse struct {
+	IncidentID    string `json:"incidentId"`
+	IncidentTitle string `json:"incidentTitle"`
+	Body          string `json:"body"`
+	PublishedAt   string `json:"publishedAt"`
+}
+
+// buildPublicPostmortem converts a published postmortem for the public API
+func buildPublicPostmortem(postmortem models.Postmortem, incident models.Incident) PublicPostmortemResponse {
+	response := PublicPostmortemResponse{
+		IncidentID:    incident.ID,
+		IncidentTitle: incident.Title,
+		Body:          postmortem.Body,
+	}
+	if postmortem.PublishedAt != nil {
+		response.PublishedAt = postmortem.PublishedAt.UTC().Format(publicTimeFormat)
+	}
+	return response
+}
+
+// isPublicPostmortem reports whether a postmortem is shown on the public status page
+func isPublicPostmortem(postmortem models.Postmortem) bool {
+	return postmortem.Status == models.PostmortemPublished && postmortem.PublishToPublic
+}
+
+// findOrgIncident returns an incident of the organization, writing the error response if it cannot
+func findOrgIncident(c *gin.Context, tx *gorm.DB, orgID interface{}, incidentID string) (models.Incident, bool) {
+	var incident models.Incident
+	if err := tx.Where("id = ? AND org_id = ?", incidentID, orgID).First(&incident).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident"})
+		}
+		return incident, false
+	}
+	return incident, true
+}
+
+// GetPostmortem returns the postmortem of an incident
+func GetPostmortem(c *gin.Context) {
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	incident, ok := findOrgIncident(c, db.DB, orgID, incidentID)
+	if !ok {
+		return
+	}
+
+	var postmortem models.Postmortem
+	if err := db.DB.Where("incident_id = ?", incident.ID).First(&postmortem).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Postmortem not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve postmortem"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"postmortem": postmortem})
+}
+
+// SavePostmortem creates or updates the postmortem of a resolved incident. A new postmortem
+// starts as a draft; editing a published postmortem keeps it published. Connected status page
+// clients are notified when a published postmortem is newly shown on the public page.
+func SavePostmortem(c *gin.Context) {
+	var req PostmortemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	tx := db.DB.Begin()
+
+	incident, ok := findOrgIncident(c, tx, orgID, incidentID)
+	if !ok {
+		tx.Rollback()
+		return
+	}
+
+	// A deleted postmortem still holds the incident's unique index, so it is looked up as well
+	var postmortem models.Postmortem
+	err := tx.Unscoped().Where("incident_id = ?", incident.ID).First(&postmortem).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve postmortem"})
+		return
+	}
+
+	status := http.StatusOK
+	if err == gorm.ErrRecordNotFound || postmortem.DeletedAt.Valid {
+		if incident.Status != models.IncidentResolved {
+			tx.Rollback()
+			c.JSON(http.StatusConflict, gin.H{"error": "Postmortems can only be written for resolved incidents"})
+			return
+		}
+
+		id := utils.GenerateUUID()
+		if postmortem.DeletedAt.Valid {
+			id = postmortem.ID
+		}
+
+		postmortem = models.Postmortem{
+			ID:         id,
+			IncidentID: incident.ID,
+			OrgID:      orgID.(string),
+			Status:     models.PostmortemDraft,
+			CreatedAt:  time.Now(),
+		}
+		status = http.StatusCreated
+	}
+
+	wasPublic := isPublicPostmortem(postmortem)
+
+	postmortem.Body = req.Body
+	postmortem.PublishToPublic = req.PublishToPublic
+	postmortem.AuthorID = userID.(string)
+
+	// Unscoped so a deleted postmortem is written over and restored
+	if err := tx.Unscoped().Save(&postmortem).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save postmortem"})
+		return
+	}
+
+	tx.Commit()
+
+	if !wasPublic && isPublicPostmortem(postmortem) {
+		BroadcastPostmortemPublished(incident.OrgID, buildPublicPostmortem(postmortem, incident))
+	}
+
+	c.JSON(status, gin.H{"postmortem": postmortem})
+}
+
+// PublishPostmortem publishes a draft postmortem and, when it is shown on the public page,
+// notifies connected status page clients
+func PublishPostmortem(c *gin.Context) {
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	incident, ok := findOrgIncident(c, tx, orgID, incidentID)
+	if !ok {
+		tx.Rollback()
+		return
+	}
+
+	var postmortem models.Postmortem
+	if err := tx.Where("incident_id = ?", incident.ID).First(&postmortem).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Postmortem not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve postmortem"})
+		}
+		return
+	}
+
+	if postmortem.Status == models.PostmortemPublished {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": "Postmortem is already published"})
+		return
+	}
+
+	now := time.Now()
+	postmortem.Status = models.PostmortemPublished
+	postmortem.PublishedAt = &now
+
+	if err := tx.Save(&postmortem).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish postmortem"})
+		return
+	}
+
+	tx.Commit()
+
+	// Status page clients connect without authentication, so only public postmortems are announced
+	if isPublicPostmortem(postmortem) {
+		BroadcastPostmortemPublished(incident.OrgID, buildPublicPostmortem(postmortem, incident))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"postmortem": postmortem})
+}
+
+// DeletePostmortem deletes the postmortem of an incident
+func DeletePostmortem(c *gin.Context) {
+	incidentID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	incident, ok := findOrgIncident(c, db.DB, orgID, incidentID)
+	if !ok {
+		return
+	}
+
+	result := db.DB.Where("incident_id = ?", incident.ID).Delete(&models.Postmortem{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete postmortem"})
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postmortem not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Postmortem deleted successfully"})
+}
+
+// GetPublicPostmortem returns the published postmortem of an incident for the public status page
+func GetPublicPostmortem(c *gin.Context) {
+	orgID := c.Param("orgId")
+	incidentID := c.Param("id")
+
+	var incident models.Incident
+	if err := db.DB.Where("id = ? AND org_id = ?", incidentID, orgID).First(&incident).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Postmortem not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident"})
+		}
+		return
+	}
+
+	var postmortem models.Postmortem
+	if err := db.DB.Where("incident_id = ? AND status = ? AND publish_to_public = ?",
+		incident.ID, models.PostmortemPublished, true).First(&postmortem).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Postmortem not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve postmortem"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"postmortem": buildPublicPostmortem(postmortem, incident)})
+}
@@ -1,13 +1,151 @@
 package api
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/status_page/backend/db"
 	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
 )
 
+// PublicServiceGroup represents a service group with its member services for the public API
+type PublicServiceGroup struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	DisplayOrder int              `json:"displayOrder"`
+	Collapsed    bool             `json:"collapsed"`
+	Status       string           `json:"status"`
+	Services     []models.Service `json:"services"`
+}
+
+// publicTimeFormat is the timestamp format used by the public API
+const publicTimeFormat = "2006-01-02T15:04:05Z"
+
+// PageStatus summarizes the overall status of an organization's status page
+type PageStatus struct {
+	Status      string `json:"status"`
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+}
+
+// overallPageStatus derives the page status from the worst status among the given services
+func overallPageStatus(services []models.Service) PageStatus {
+	worst := models.WorstServiceStatus(aggregateServiceStatus(services))
+	return PageStatus{
+		Status:      worst.Name,
+		Indicator:   worst.Indicator,
+		Description: worst.Description,
+	}
+}
+
+// findPublicService returns a publicly visible service of an organization, writing the error
+// response if there is none
+func findPublicService(c *gin.Context, orgID, serviceID string) (models.Service, bool) {
+	var service models.Service
+	if err := db.DB.Where("id = ? AND org_id = ? AND hidden = ?", serviceID, orgID, false).First(&service).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service"})
+		}
+		return service, false
+	}
+	return service, true
+}
+
+// writeCached writes a public response that clients may cache for maxAge seconds, tagged with
+// an ETag so that a client revalidating an unchanged response gets 304 Not Modified
+func writeCached(c *gin.Context, contentType string, body []byte, maxAge int) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	c.Header("ETag", etag)
+
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// GetStatuses returns every supported service status ordered by severity
+func GetStatuses(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"statuses": models.ServiceStatuses})
+}
+
+// loadPublicServices returns an organization's publicly visible services in display order along
+// with its service groups, each group carrying its member services and their aggregated status
+func loadPublicServices(orgID string) ([]models.Service, []PublicServiceGroup, error) {
+	var services []models.Service
+	if err := db.DB.Where("org_id = ? AND hidden = ?", orgID, false).
+		Order("display_order ASC, name ASC").
+		Find(&services).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyServiceImpact(orgID, services); err != nil {
+		return nil, nil, err
+	}
+
+	// Impact may originate from internal-only services, which must not be referenced publicly
+	visible := make(map[string]bool, len(services))
+	for _, service := range services {
+		visible[service.ID] = true
+	}
+	for i := range services {
+		var impactedBy []string
+		for _, upstreamID := range services[i].ImpactedBy {
+			if visible[upstreamID] {
+				impactedBy = append(impactedBy, upstreamID)
+			}
+		}
+		services[i].ImpactedBy = impactedBy
+	}
+
+	var groups []models.ServiceGroup
+	if err := db.DB.Where("org_id = ?", orgID).
+		Order("display_order ASC, name ASC").
+		Find(&groups).Error; err != nil {
+		return nil, nil, err
+	}
+
+	publicGroups := make([]PublicServiceGroup, 0, len(groups))
+	for _, group := range groups {
+		members := make([]models.Service, 0)
+		for _, service := range services {
+			if service.GroupID != nil && *service.GroupID == group.ID {
+				members = append(members, service)
+			}
+		}
+
+		// Groups containing only internal-only services are not shown publicly
+		if len(members) == 0 {
+			continue
+		}
+
+		publicGroups = append(publicGroups, PublicServiceGroup{
+			ID:           group.ID,
+			Name:         group.Name,
+			DisplayOrder: group.DisplayOrder,
+			Collapsed:    group.Collapsed,
+			Status:       aggregateServiceStatus(members),
+			Services:     members,
+		})
+	}
+
+	return services, publicGroups, nil
+}
+
 // GetPublicServices returns services for a public status page
 func GetPublicServices(c *gin.Context) {
 	orgID := c.Param("orgId")
@@ -17,86 +155,198 @@ func GetPublicServices(c *gin.Context) {
 	}
 
 	// --->>here<<--- Database query to get all services for an organization for the public page
-	var services []models.Service
-	if err := db.DB.Where("org_id = ?", orgID).Find(&services).Error; err != nil {
+	services, groups, err := loadPublicServices(orgID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"services": services})
+	c.JSON(http.StatusOK, gin.H{
+		"status":   overallPageStatus(services),
+		"services": services,
+		"groups":   groups,
+	})
 }
 
 // PublicIncidentResponse represents an incident with its services and updates for the public API
 type PublicIncidentResponse struct {
-	ID          string                  `json:"id"`
-	Title       string                  `json:"title"`
-	Description string                  `json:"description"`
-	Status      string                  `json:"status"`
-	CreatedAt   string                  `json:"createdAt"`
-	UpdatedAt   string                  `json:"updatedAt"`
-	Services    []models.Service        `json:"services"`
-	Updates     []models.IncidentUpdate `json:"updates"`
+	ID          string                 `json:"id"`
+	Slug        string                 `json:"slug"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Status      string                 `json:"status"`
+	Impact      string                 `json:"impact"`
+	CreatedAt   string                 `json:"createdAt"`
+	UpdatedAt   string                 `json:"updatedAt"`
+	Services    []models.Service       `json:"services"`
+	Updates     []PublicIncidentUpdate `json:"updates"`
 }
 
-// GetPublicIncidents returns active incidents for a public status page
-func GetPublicIncidents(c *gin.Context) {
-	orgID := c.Param("orgId")
-	if orgID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID is required"})
-		return
+// PublicIncidentUpdate represents a step of an incident timeline for the public API
+type PublicIncidentUpdate struct {
+	ID             string                `json:"id"`
+	Message        string                `json:"message"`
+	Status         string                `json:"status"`
+	ServiceChanges []PublicServiceChange `json:"serviceChanges"`
+	Edited         bool                  `json:"edited"`
+	CreatedAt      string                `json:"createdAt"`
+}
+
+// PublicServiceChange represents a service status change made by an incident update
+type PublicServiceChange struct {
+	ServiceID      string `json:"serviceId"`
+	ServiceName    string `json:"serviceName"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+}
+
+// buildPublicIncidentUpdates converts incident updates into a public timeline, dropping
+// status changes of services that are not publicly visible
+func buildPublicIncidentUpdates(updates []models.IncidentUpdate, visibleServices []models.Service) []PublicIncidentUpdate {
+	serviceNames := make(map[string]string, len(visibleServices))
+	for _, service := range visibleServices {
+		serviceNames[service.ID] = service.Name
+	}
+
+	timeline := make([]PublicIncidentUpdate, 0, len(updates))
+	for _, update := range updates {
+		changes := make([]PublicServiceChange, 0, len(update.ServiceChanges))
+		for _, change := range update.ServiceChanges {
+			name, ok := serviceNames[change.ServiceID]
+			if !ok {
+				continue
+			}
+
+			changes = append(changes, PublicServiceChange{
+				ServiceID:      change.ServiceID,
+				ServiceName:    name,
+				PreviousStatus: change.PreviousStatus,
+				Status:         change.Status,
+			})
+		}
+
+		timeline = append(timeline, PublicIncidentUpdate{
+			ID:             update.ID,
+			Message:        update.Message,
+			Status:         update.Status,
+			ServiceChanges: changes,
+			Edited:         update.EditedAt != nil,
+			CreatedAt:      update.CreatedAt.UTC().Format(publicTimeFormat),
+		})
 	}
 
+	return timeline
+}
+
+// buildPublicIncident loads the publicly visible services and the updates of an incident
+func buildPublicIncident(incident models.Incident) (PublicIncidentResponse, error) {
+	var incidentServices []models.Service
+	var incidentServiceIDs []string
+
+	// Get service IDs for this incident
+	if err := db.DB.Model(&models.IncidentService{}).
+		Where("incident_id = ?", incident.ID).
+		Pluck("service_id", &incidentServiceIDs).Error; err != nil {
+		return PublicIncidentResponse{}, err
+	}
+
+	// Get the actual services, leaving out internal-only ones
+	if len(incidentServiceIDs) > 0 {
+		if err := db.DB.Where("id IN ? AND hidden = ?", incidentServiceIDs, false).
+			Order("display_order ASC, name ASC").
+			Find(&incidentServices).Error; err != nil {
+			return PublicIncidentResponse{}, err
+		}
+	}
+
+	// Get updates for this incident
+	var updates []models.IncidentUpdate
+	if err := db.DB.Preload("ServiceChanges").
+		Where("incident_id = ?", incident.ID).
+		Order("created_at DESC").
+		Find(&updates).Error; err != nil {
+		return PublicIncidentResponse{}, err
+	}
+
+	response := PublicIncidentResponse{
+		ID:          incident.ID,
+		Title:       incident.Title,
+		Description: incident.Description,
+		Status:      incident.Status,
+		Impact:      incident.Impact,
+		CreatedAt:   incident.CreatedAt.UTC().Format(publicTimeFormat),
+		UpdatedAt:   incident.UpdatedAt.UTC().Format(publicTimeFormat),
+		Services:    incidentServices,
+		Updates:     buildPublicIncidentUpdates(updates, incidentServices),
+	}
+	if incident.Slug != nil {
+		response.Slug = *incident.Slug
+	}
+
+	return response, nil
+}
+
+// loadActivePublicIncidents returns an organization's unresolved incidents, newest first
+func loadActivePublicIncidents(orgID string) ([]PublicIncidentResponse, error) {
 	// --->>here<<--- Database query to get active incidents (non-resolved) for the public page
 	var incidents []models.Incident
 	if err := db.DB.Where("org_id = ? AND status != ?", orgID, "Resolved").
 		Order("created_at DESC").
 		Find(&incidents).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
-		return
+		return nil, err
 	}
 
 	// For each incident, get the associated services and updates
-	var responses []PublicIncidentResponse
+	responses := make([]PublicIncidentResponse, 0, len(incidents))
 	for _, incident := range incidents {
-		var incidentServices []models.Service
-		var incidentServiceIDs []string
-
-		// Get service IDs for this incident
-		if err := db.DB.Model(&models.IncidentService{}).
-			Where("incident_id = ?", incident.ID).
-			Pluck("service_id", &incidentServiceIDs).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident services"})
-			return
+		response, err := buildPublicIncident(incident)
+		if err != nil {
+			return nil, err
 		}
+		responses = append(responses, response)
+	}
 
-		// Get the actual services
-		if len(incidentServiceIDs) > 0 {
-			if err := db.DB.Where("id IN ?", incidentServiceIDs).Find(&incidentServices).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
-				return
-			}
-		}
+	return responses, nil
+}
 
-		// Get updates for this incident
-		var updates []models.IncidentUpdate
-		if err := db.DB.Where("incident_id = ?", incident.ID).
-			Order("created_at DESC").
-			Find(&updates).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident updates"})
-			return
-		}
+// GetPublicIncidents returns active incidents for a public status page
+func GetPublicIncidents(c *gin.Context) {
+	orgID := c.Param("orgId")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID is required"})
+		return
+	}
 
-		responses = append(responses, PublicIncidentResponse{
-			ID:          incident.ID,
-			Title:       incident.Title,
-			Description: incident.Description,
-			Status:      incident.Status,
-			CreatedAt:   incident.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt:   incident.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-			Services:    incidentServices,
-			Updates:     updates,
-		})
+	responses, err := loadActivePublicIncidents(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"incidents": responses})
 }
+
+// GetPublicIncident returns a single incident, resolved or not, with its full timeline for a
+// public status page. The incident may be referenced by its ID or by its short slug.
+func GetPublicIncident(c *gin.Context) {
+	orgID := c.Param("orgId")
+	key := c.Param("id")
+
+	var incident models.Incident
+	if err := db.DB.Where("org_id = ? AND (id = ? OR slug = ?)", orgID, key, key).First(&incident).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incident not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident"})
+		}
+		return
+	}
+
+	response, err := buildPublicIncident(incident)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incident"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"incident": response})
+}
@@ -12,8 +12,31 @@ import (
 
 // ServiceRequest represents the request for creating/updating a service
 type ServiceRequest struct {
-	Name   string `json:"name" binding:"required"`
-	Status string `json:"status" binding:"required"`
+	Name         string  `json:"name" binding:"required"`
+	Description  string  `json:"description"`
+	HelpURL      string  `json:"helpUrl" binding:"omitempty,url"`
+	Status       string  `json:"status" binding:"required"`
+	GroupID      *string `json:"groupId"`
+	DisplayOrder int     `json:"displayOrder"`
+	Hidden       bool    `json:"hidden"`
+	ShowUptime   bool    `json:"showUptime"`
+}
+
+// resolveServiceGroup checks that the requested group belongs to the organization,
+// returning nil when the service should not belong to any group
+func resolveServiceGroup(orgID interface{}, groupID *string) (*string, bool, error) {
+	if groupID == nil || *groupID == "" {
+		return nil, true, nil
+	}
+
+	var count int64
+	if err := db.DB.Model(&models.ServiceGroup{}).
+		Where("id = ? AND org_id = ?", *groupID, orgID).
+		Count(&count).Error; err != nil {
+		return nil, false, err
+	}
+
+	return groupID, count == 1, nil
 }
 
 // GetServices returns all services for the user's organization
@@ -22,11 +45,18 @@ func GetServices(c *gin.Context) {
 
 	// --->>here<<--- Database query to get all services for an organization
 	var services []models.Service
-	if err := db.DB.Where("org_id = ?", orgID).Find(&services).Error; err != nil {
+	if err := db.DB.Where("org_id = ?", orgID).
+		Order("display_order ASC, name ASC").
+		Find(&services).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
 		return
 	}
 
+	if err := applyServiceImpact(orgID, services); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute service impact"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"services": services})
 }
 
@@ -46,7 +76,13 @@ func GetService(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"service": service})
+	impacted := []models.Service{service}
+	if err := applyServiceImpact(orgID, impacted); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute service impact"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"service": impacted[0]})
 }
 
 // CreateService creates a new service
@@ -60,23 +96,34 @@ func CreateService(c *gin.Context) {
 	orgID, _ := c.Get("org_id")
 
 	// Validate status
-	validStatuses := map[string]bool{
-		"Operational": true,
-		"Degraded":    true,
-		"Outage":      true,
+	if !models.IsValidServiceStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+		return
 	}
 
-	if !validStatuses[req.Status] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+	// Validate group
+	groupID, ok, err := resolveServiceGroup(orgID, req.GroupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate service group"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Service group not found"})
 		return
 	}
 
 	// --->>here<<--- Database operation to create a new service
 	service := models.Service{
-		ID:     utils.GenerateUUID(),
-		Name:   req.Name,
-		Status: req.Status,
-		OrgID:  orgID.(string),
+		ID:           utils.GenerateUUID(),
+		Name:         req.Name,
+		Description:  req.Description,
+		HelpURL:      req.HelpURL,
+		Status:       req.Status,
+		OrgID:        orgID.(string),
+		GroupID:      groupID,
+		DisplayOrder: req.DisplayOrder,
+		Hidden:       req.Hidden,
+		ShowUptime:   req.ShowUptime,
 	}
 
 	if err := db.DB.Create(&service).Error; err != nil {
@@ -99,14 +146,19 @@ func UpdateService(c *gin.Context) {
 	orgID, _ := c.Get("org_id")
 
 	// Validate status
-	validStatuses := map[string]bool{
-		"Operational": true,
-		"Degraded":    true,
-		"Outage":      true,
+	if !models.IsValidServiceStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+		return
 	}
 
-	if !validStatuses[req.Status] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status value"})
+	// Validate group
+	groupID, ok, err := resolveServiceGroup(orgID, req.GroupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate service group"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Service group not found"})
 		return
 	}
 
@@ -123,7 +175,13 @@ func UpdateService(c *gin.Context) {
 
 	// Update service
 	service.Name = req.Name
+	service.Description = req.Description
+	service.HelpURL = req.HelpURL
 	service.Status = req.Status
+	service.GroupID = groupID
+	service.DisplayOrder = req.DisplayOrder
+	service.Hidden = req.Hidden
+	service.ShowUptime = req.ShowUptime
 
 	if err := db.DB.Save(&service).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service"})
@@ -149,10 +207,36 @@ func DeleteService(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&service).Error; err != nil {
+	tx := db.DB.Begin()
+
+	// Remove dependencies in either direction
+	if err := tx.Where("service_id = ? OR depends_on_id = ?", service.ID, service.ID).
+		Delete(&models.ServiceDependency{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete service dependencies"})
+		return
+	}
+
+	// Drop the service from template and maintenance series defaults
+	if err := tx.Where("service_id = ?", service.ID).Delete(&models.IncidentTemplateService{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template services"})
+		return
+	}
+
+	if err := tx.Where("service_id = ?", service.ID).Delete(&models.MaintenanceSeriesService{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete maintenance series services"})
+		return
+	}
+
+	if err := tx.Delete(&service).Error; err != nil {
+		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete service"})
 		return
 	}
 
+	tx.Commit()
+
 	c.JSON(http.StatusOK, gin.H{"message": "Service deleted successfully"})
 }
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// ServiceGroupRequest represents the request for creating/updating a service group
+type ServiceGroupRequest struct {
+	Name         string `json:"name" binding:"required"`
+	DisplayOrder int    `json:"displayOrder"`
+	Collapsed    bool   `json:"collapsed"`
+}
+
+// aggregateServiceStatus returns the worst status among the given services
+func aggregateServiceStatus(services []models.Service) string {
+	statuses := make([]string, 0, len(services))
+	for _, service := range services {
+		statuses = append(statuses, service.Status)
+	}
+	return models.WorstServiceStatus(statuses...).Name
+}
+
+// GetServiceGroups returns all service groups for the user's organization
+func GetServiceGroups(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	var groups []models.ServiceGroup
+	if err := db.DB.Where("org_id = ?", orgID).
+		Order("display_order ASC, name ASC").
+		Find(&groups).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service groups"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// GetServiceGroup returns a specific service group with its services
+func GetServiceGroup(c *gin.Context) {
+	groupID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var group models.ServiceGroup
+	if err := db.DB.Where("id = ? AND org_id = ?", groupID, orgID).First(&group).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service group not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service group"})
+		}
+		return
+	}
+
+	var services []models.Service
+	if err := db.DB.Where("group_id = ? AND org_id = ?", group.ID, orgID).
+		Order("display_order ASC, name ASC").
+		Find(&services).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"group":    group,
+		"status":   aggregateServiceStatus(services),
+		"services": services,
+	})
+}
+
+// CreateServiceGroup creates a new service group
+func CreateServiceGroup(c *gin.Context) {
+	var req ServiceGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgID, _ := c.Get("org_id")
+
+	group := models.ServiceGroup{
+		ID:           utils.GenerateUUID(),
+		Name:         req.Name,
+		DisplayOrder: req.DisplayOrder,
+		Collapsed:    req.Collapsed,
+		OrgID:        orgID.(string),
+	}
+
+	if err := db.DB.Create(&group).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create service group"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"group": group})
+}
+
+// UpdateServiceGroup updates an existing service group
+func UpdateServiceGroup(c *gin.Context) {
+	var req ServiceGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groupID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	var group models.ServiceGroup
+	if err := db.DB.Where("id = ? AND org_id = ?", groupID, orgID).First(&group).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service group not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service group"})
+		}
+		return
+	}
+
+	group.Name = req.Name
+	group.DisplayOrder = req.DisplayOrder
+	group.Collapsed = req.Collapsed
+
+	if err := db.DB.Save(&group).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service group"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"group": group})
+}
+
+// DeleteServiceGroup deletes a service group, leaving its services ungrouped
+func DeleteServiceGroup(c *gin.Context) {
+	groupID := c.Param("id")
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	var group models.ServiceGroup
+	if err := tx.Where("id = ? AND org_id = ?", groupID, orgID).First(&group).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service group not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve service group"})
+		}
+		return
+	}
+
+	// Detach member services from the group
+	if err := tx.Model(&models.Service{}).
+		Where("group_id = ?", group.ID).
+		Update("group_id", nil).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ungroup services"})
+		return
+	}
+
+	if err := tx.Delete(&group).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete service group"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Service group deleted successfully"})
+}
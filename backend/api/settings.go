@@ -0,0 +1,280 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+)
+
+// colorPattern matches a hex color such as #1f6feb or #fff
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// SettingsRequest represents the request for updating an organization's status page settings
+type SettingsRequest struct {
+	Name            string           `json:"name" binding:"required,max=100"`
+	LogoURL         string           `json:"logoUrl" binding:"max=2048"`
+	FaviconURL      string           `json:"faviconUrl" binding:"max=2048"`
+	PrimaryColor    string           `json:"primaryColor"`
+	HeaderTextColor string           `json:"headerTextColor"`
+	HeaderText      string           `json:"headerText" binding:"max=200"`
+	FooterLinks     []PageFooterLink `json:"footerLinks" binding:"max=10,dive"`
+	SupportURL      string           `json:"supportUrl" binding:"max=2048"`
+	Timezone        string           `json:"timezone"`
+	About           string           `json:"about" binding:"max=2000"`
+}
+
+// PageFooterLink is a link in the footer of the public status page
+type PageFooterLink struct {
+	Label string `json:"label" binding:"required,max=50"`
+	URL   string `json:"url" binding:"required,max=2048"`
+}
+
+// PageBranding is how an organization's public status page looks
+type PageBranding struct {
+	LogoURL         string           `json:"logoUrl"`
+	FaviconURL      string           `json:"faviconUrl"`
+	PrimaryColor    string           `json:"primaryColor"`
+	HeaderTextColor string           `json:"headerTextColor"`
+	HeaderText      string           `json:"headerText"`
+	FooterLinks     []PageFooterLink `json:"footerLinks"`
+	SupportURL      string           `json:"supportUrl"`
+	Timezone        string           `json:"timezone"`
+	About           string           `json:"about"`
+}
+
+// SettingsResponse represents the status page settings of an organization
+type SettingsResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	PageBranding
+	Domains []CustomDomainResponse `json:"domains"`
+}
+
+// PublicPageConfig is what the frontend needs to brand an organization's public status page
+type PublicPageConfig struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	PageBranding
+}
+
+// isPageURL reports whether a configured link is empty or an absolute http(s) URL
+func isPageURL(value string) bool {
+	if value == "" {
+		return true
+	}
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// orgLocation returns the time zone an organization's dates are displayed in
+func orgLocation(org models.Organization) *time.Location {
+	location, err := time.LoadLocation(org.Timezone)
+	if err != nil || org.Timezone == "" {
+		return time.UTC
+	}
+	return location
+}
+
+// loadPageBranding returns the branding of an organization's public status page
+func loadPageBranding(org models.Organization) (PageBranding, error) {
+	var links []models.FooterLink
+	if err := db.DB.Where("org_id = ?", org.ID).Order("display_order ASC").Find(&links).Error; err != nil {
+		return PageBranding{}, err
+	}
+
+	footerLinks := make([]PageFooterLink, 0, len(links))
+	for _, link := range links {
+		footerLinks = append(footerLinks, PageFooterLink{Label: link.Label, URL: link.URL})
+	}
+
+	timezone := org.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	return PageBranding{
+		LogoURL:         org.LogoURL,
+		FaviconURL:      org.FaviconURL,
+		PrimaryColor:    org.PrimaryColor,
+		HeaderTextColor: org.HeaderTextColor,
+		HeaderText:      org.HeaderText,
+		FooterLinks:     footerLinks,
+		SupportURL:      org.SupportURL,
+		Timezone:        timezone,
+		About:           org.About,
+	}, nil
+}
+
+// respondWithSettings writes the status page settings of an organization
+func respondWithSettings(c *gin.Context, org models.Organization) {
+	branding, err := loadPageBranding(org)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve page branding"})
+		return
+	}
+
+	domains, err := loadCustomDomains(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve custom domains"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": SettingsResponse{
+		ID:           org.ID,
+		Name:         org.Name,
+		PageBranding: branding,
+		Domains:      domains,
+	}})
+}
+
+// GetSettings returns the status page settings of the user's organization, including its
+// branding and the custom domains its page is served on
+func GetSettings(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	respondWithSettings(c, org)
+}
+
+// UpdateSettings updates the name and page branding of the user's organization. Footer links
+// are replaced by the given list.
+func UpdateSettings(c *gin.Context) {
+	var req SettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, link := range append([]string{req.LogoURL, req.FaviconURL, req.SupportURL}, footerLinkURLs(req.FooterLinks)...) {
+		if !isPageURL(link) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid URL: " + link})
+			return
+		}
+	}
+
+	for _, color := range []string{req.PrimaryColor, req.HeaderTextColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid color: " + color})
+			return
+		}
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	// Local would be the server's own time zone
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "Local" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+		return
+	}
+
+	orgID, _ := c.Get("org_id")
+
+	tx := db.DB.Begin()
+
+	var org models.Organization
+	if err := tx.Where("id = ?", orgID).First(&org).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	org.Name = req.Name
+	org.LogoURL = req.LogoURL
+	org.FaviconURL = req.FaviconURL
+	org.PrimaryColor = req.PrimaryColor
+	org.HeaderTextColor = req.HeaderTextColor
+	org.HeaderText = req.HeaderText
+	org.SupportURL = req.SupportURL
+	org.Timezone = req.Timezone
+	org.About = req.About
+
+	// Selecting the columns saves cleared fields as well
+	if err := tx.Model(&org).
+		Select("name", "logo_url", "favicon_url", "primary_color", "header_text_color", "header_text", "support_url", "timezone", "about").
+		Updates(&org).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update settings"})
+		return
+	}
+
+	if err := tx.Where("org_id = ?", org.ID).Delete(&models.FooterLink{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update footer links"})
+		return
+	}
+
+	for i, link := range req.FooterLinks {
+		footerLink := models.FooterLink{
+			ID:           utils.GenerateUUID(),
+			OrgID:        org.ID,
+			Label:        link.Label,
+			URL:          link.URL,
+			DisplayOrder: i,
+		}
+		if err := tx.Create(&footerLink).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update footer links"})
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
+		return
+	}
+
+	respondWithSettings(c, org)
+}
+
+// footerLinkURLs returns the URLs of the given footer links
+func footerLinkURLs(links []PageFooterLink) []string {
+	urls := make([]string, 0, len(links))
+	for _, link := range links {
+		urls = append(urls, link.URL)
+	}
+	return urls
+}
+
+// GetPublicPageConfig returns the name and branding of an organization's public status page
+func GetPublicPageConfig(c *gin.Context) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	branding, err := loadPageBranding(org)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve page branding"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"config": PublicPageConfig{ID: org.ID, Name: org.Name, PageBranding: branding}})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// Bounds of the auto-refresh interval of the server-rendered status page, in seconds
+const (
+	minStatusPageRefresh = 10
+	maxStatusPageRefresh = 3600
+)
+
+var statusPageTemplate = template.Must(template.New("status_page.html").Funcs(template.FuncMap{
+	"statusColor":  func(status string) string { return models.WorstServiceStatus(status).Color },
+	"serviceNames": joinServiceNames,
+	"displayTime":  displayTime,
+}).ParseFS(templateFS, "templates/status_page.html"))
+
+// StatusPageData is what the server-rendered status page is generated from
+type StatusPageData struct {
+	Page         WidgetPage
+	Branding     PageBranding
+	Status       PageStatus
+	Groups       []PublicServiceGroup
+	Services     []models.Service // Services outside any group
+	Incidents    []PublicIncidentResponse
+	Maintenances []PublicMaintenanceResponse
+	Refresh      int // Auto-refresh interval in seconds, 0 to disable
+	Location     *time.Location
+	GeneratedAt  string
+}
+
+// displayTime formats a public API timestamp for reading in the given time zone
+func displayTime(location *time.Location, value string) string {
+	t, err := time.Parse(publicTimeFormat, value)
+	if err != nil {
+		return value
+	}
+	return t.In(location).Format("Jan 2, 2006 15:04 MST")
+}
+
+// GetStatusPage renders an organization's public status page as plain HTML for search engines,
+// visitors without JavaScript and times when the frontend is unavailable. It shows the same
+// services, incidents and maintenances as the public API, with the organization's branding and
+// dates in its time zone. With refresh set to a number of seconds, the page reloads itself at
+// that interval.
+func GetStatusPage(c *gin.Context) {
+	orgID := c.Param("orgId")
+
+	refresh := 0
+	if value := c.Query("refresh"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			c.String(http.StatusBadRequest, "Invalid refresh interval")
+			return
+		}
+		refresh = min(max(seconds, minStatusPageRefresh), maxStatusPageRefresh)
+	}
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.String(http.StatusNotFound, "Status page not found")
+		} else {
+			c.String(http.StatusInternalServerError, "Failed to retrieve organization")
+		}
+		return
+	}
+
+	services, groups, err := loadPublicServices(org.ID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to retrieve services")
+		return
+	}
+
+	incidents, err := loadActivePublicIncidents(org.ID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to retrieve incidents")
+		return
+	}
+
+	maintenances, err := loadPublicMaintenances(org.ID, models.MaintenanceInProgress, models.MaintenanceScheduled)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to retrieve maintenances")
+		return
+	}
+
+	branding, err := loadPageBranding(org)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to retrieve page branding")
+		return
+	}
+
+	grouped := make(map[string]bool)
+	for _, group := range groups {
+		for _, service := range group.Services {
+			grouped[service.ID] = true
+		}
+	}
+
+	ungrouped := make([]models.Service, 0, len(services))
+	for _, service := range services {
+		if !grouped[service.ID] {
+			ungrouped = append(ungrouped, service)
+		}
+	}
+
+	data := StatusPageData{
+		Page:         WidgetPage{ID: org.ID, Name: org.Name, URL: publicPageURL(c, org.ID)},
+		Branding:     branding,
+		Status:       overallPageStatus(services),
+		Groups:       groups,
+		Services:     ungrouped,
+		Incidents:    incidents,
+		Maintenances: maintenances,
+		Refresh:      refresh,
+		Location:     orgLocation(org),
+		GeneratedAt:  time.Now().UTC().Format(publicTimeFormat),
+	}
+
+	var body bytes.Buffer
+	if err := statusPageTemplate.Execute(&body, data); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render status page")
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=30")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body.Bytes())
+}
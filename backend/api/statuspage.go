@@ -0,0 +1,559 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// maxStatuspageItems is the number of incidents or maintenances listed by the Statuspage-compatible API
+const maxStatuspageItems = 50
+
+// statuspageMaintenanceStatuses maps maintenance statuses to their Statuspage equivalents.
+// Cancelled maintenances have no equivalent and are left out.
+var statuspageMaintenanceStatuses = map[string]string{
+	models.MaintenanceScheduled:  "scheduled",
+	models.MaintenanceInProgress: "in_progress",
+	models.MaintenanceCompleted:  "completed",
+}
+
+// StatuspagePage describes the status page in the Statuspage v2 format
+type StatuspagePage struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	TimeZone  string `json:"time_zone"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// StatuspageStatus is the overall page status in the Statuspage v2 format
+type StatuspageStatus struct {
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+}
+
+// StatuspageComponent is a service, or a service group, in the Statuspage v2 format
+type StatuspageComponent struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Status             string   `json:"status"`
+	CreatedAt          string   `json:"created_at"`
+	UpdatedAt          string   `json:"updated_at"`
+	Position           int      `json:"position"`
+	Description        *string  `json:"description"`
+	Showcase           bool     `json:"showcase"`
+	StartDate          *string  `json:"start_date"`
+	GroupID            *string  `json:"group_id"`
+	PageID             string   `json:"page_id"`
+	Group              bool     `json:"group"`
+	OnlyShowIfDegraded bool     `json:"only_show_if_degraded"`
+	Components         []string `json:"components,omitempty"` // Member component IDs of a group
+}
+
+// StatuspageIncident is an incident or a scheduled maintenance in the Statuspage v2 format
+type StatuspageIncident struct {
+	ID              string                     `json:"id"`
+	Name            string                     `json:"name"`
+	Status          string                     `json:"status"`
+	CreatedAt       string                     `json:"created_at"`
+	UpdatedAt       string                     `json:"updated_at"`
+	MonitoringAt    *string                    `json:"monitoring_at"`
+	ResolvedAt      *string                    `json:"resolved_at"`
+	Impact          string                     `json:"impact"`
+	Shortlink       string                     `json:"shortlink"`
+	StartedAt       string                     `json:"started_at"`
+	PageID          string                     `json:"page_id"`
+	IncidentUpdates []StatuspageIncidentUpdate `json:"incident_updates"`
+	Components      []StatuspageComponent      `json:"components"`
+
+	// Only set for scheduled maintenances
+	ScheduledFor   *string `json:"scheduled_for,omitempty"`
+	ScheduledUntil *string `json:"scheduled_until,omitempty"`
+}
+
+// StatuspageIncidentUpdate is an incident update in the Statuspage v2 format
+type StatuspageIncidentUpdate struct {
+	ID                 string                        `json:"id"`
+	Status             string                        `json:"status"`
+	Body               string                        `json:"body"`
+	IncidentID         string                        `json:"incident_id"`
+	CreatedAt          string                        `json:"created_at"`
+	UpdatedAt          string                        `json:"updated_at"`
+	DisplayAt          string                        `json:"display_at"`
+	AffectedComponents []StatuspageAffectedComponent `json:"affected_components"`
+}
+
+// StatuspageAffectedComponent is a component status change carried by an incident update
+type StatuspageAffectedComponent struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+}
+
+// statuspageTime formats a timestamp for the Statuspage-compatible API
+func statuspageTime(t time.Time) string {
+	return t.UTC().Format(publicTimeFormat)
+}
+
+// statuspageOptionalTime formats an optional timestamp for the Statuspage-compatible API
+func statuspageOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := statuspageTime(*t)
+	return &formatted
+}
+
+// statuspageComponentStatus returns the Statuspage component status of a service status
+func statuspageComponentStatus(status string) string {
+	return models.WorstServiceStatus(status).Code
+}
+
+// statuspageSource holds the organization and visible services a Statuspage response is built from
+type statuspageSource struct {
+	Org      models.Organization
+	PageURL  string
+	Services []models.Service
+	Groups   []PublicServiceGroup
+}
+
+// loadStatuspageSource loads an organization and its visible services, writing the error
+// response if it cannot
+func loadStatuspageSource(c *gin.Context) (statuspageSource, bool) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return statuspageSource{}, false
+	}
+
+	services, groups, err := loadPublicServices(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+		return statuspageSource{}, false
+	}
+
+	return statuspageSource{
+		Org:      org,
+		PageURL:  publicPageURL(c, org.ID),
+		Services: services,
+		Groups:   groups,
+	}, true
+}
+
+// page describes the status page, last updated when the organization or any visible service changed
+func (s statuspageSource) page() StatuspagePage {
+	updated := s.Org.UpdatedAt
+	for _, service := range s.Services {
+		if service.UpdatedAt.After(updated) {
+			updated = service.UpdatedAt
+		}
+	}
+
+	return StatuspagePage{
+		ID:        s.Org.ID,
+		Name:      s.Org.Name,
+		URL:       s.PageURL,
+		TimeZone:  statuspageTimeZone(s.Org),
+		UpdatedAt: statuspageTime(updated),
+	}
+}
+
+// statuspageTimeZone returns the IANA time zone of an organization as Statuspage names it
+func statuspageTimeZone(org models.Organization) string {
+	if org.Timezone == "" || org.Timezone == "UTC" {
+		return "Etc/UTC"
+	}
+	return org.Timezone
+}
+
+// status summarizes the page from the worst status among its visible services
+func (s statuspageSource) status() StatuspageStatus {
+	status := overallPageStatus(s.Services)
+	return StatuspageStatus{
+		Indicator:   status.Indicator,
+		Description: status.Description,
+	}
+}
+
+// component converts a service into a component at the given position
+func (s statuspageSource) component(service models.Service, position int) StatuspageComponent {
+	component := StatuspageComponent{
+		ID:        service.ID,
+		Name:      service.Name,
+		Status:    statuspageComponentStatus(service.Status),
+		CreatedAt: statuspageTime(service.CreatedAt),
+		UpdatedAt: statuspageTime(service.UpdatedAt),
+		Position:  position,
+		Showcase:  true,
+		GroupID:   service.GroupID,
+		PageID:    s.Org.ID,
+	}
+	if service.Description != "" {
+		description := service.Description
+		component.Description = &description
+	}
+	return component
+}
+
+// components lists every group followed by its member services, then the ungrouped services
+func (s statuspageSource) components() []StatuspageComponent {
+	components := make([]StatuspageComponent, 0, len(s.Services)+len(s.Groups))
+	position := 1
+
+	grouped := make(map[string]bool)
+	for _, group := range s.Groups {
+		groupComponent := StatuspageComponent{
+			ID:         group.ID,
+			Name:       group.Name,
+			Status:     statuspageComponentStatus(group.Status),
+			Position:   position,
+			PageID:     s.Org.ID,
+			Group:      true,
+			Components: make([]string, 0, len(group.Services)),
+		}
+		position++
+
+		members := make([]StatuspageComponent, 0, len(group.Services))
+		created, updated := time.Time{}, time.Time{}
+		for _, service := range group.Services {
+			grouped[service.ID] = true
+			groupComponent.Components = append(groupComponent.Components, service.ID)
+			members = append(members, s.component(service, position))
+			position++
+
+			if created.IsZero() || service.CreatedAt.Before(created) {
+				created = service.CreatedAt
+			}
+			if service.UpdatedAt.After(updated) {
+				updated = service.UpdatedAt
+			}
+		}
+
+		groupComponent.CreatedAt = statuspageTime(created)
+		groupComponent.UpdatedAt = statuspageTime(updated)
+		components = append(components, groupComponent)
+		components = append(components, members...)
+	}
+
+	for _, service := range s.Services {
+		if grouped[service.ID] {
+			continue
+		}
+		components = append(components, s.component(service, position))
+		position++
+	}
+
+	return components
+}
+
+// visibleComponents returns the components of the given services that are publicly visible
+func (s statuspageSource) visibleComponents(serviceIDs []string) []StatuspageComponent {
+	affected := make(map[string]bool, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		affected[serviceID] = true
+	}
+
+	components := []StatuspageComponent{}
+	for _, component := range s.components() {
+		if !component.Group && affected[component.ID] {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// incidents converts incidents, with their updates and affected components
+func (s statuspageSource) incidents(incidents []models.Incident) ([]StatuspageIncident, error) {
+	serviceNames := make(map[string]string, len(s.Services))
+	for _, service := range s.Services {
+		serviceNames[service.ID] = service.Name
+	}
+
+	responses := make([]StatuspageIncident, 0, len(incidents))
+	for _, incident := range incidents {
+		var serviceIDs []string
+		if err := db.DB.Model(&models.IncidentService{}).
+			Where("incident_id = ?", incident.ID).
+			Pluck("service_id", &serviceIDs).Error; err != nil {
+			return nil, err
+		}
+
+		var updates []models.IncidentUpdate
+		if err := db.DB.Preload("ServiceChanges").
+			Where("incident_id = ?", incident.ID).
+			Order("created_at DESC").
+			Find(&updates).Error; err != nil {
+			return nil, err
+		}
+
+		// Resolved incidents with a public postmortem are reported in the postmortem state
+		status := strings.ToLower(incident.Status)
+		if incident.Status == models.IncidentResolved {
+			var postmortems int64
+			if err := db.DB.Model(&models.Postmortem{}).
+				Where("incident_id = ? AND status = ? AND publish_to_public = ?", incident.ID, models.PostmortemPublished, true).
+				Count(&postmortems).Error; err != nil {
+				return nil, err
+			}
+			if postmortems > 0 {
+				status = "postmortem"
+			}
+		}
+
+		shortlink := s.PageURL
+		if incident.Slug != nil {
+			shortlink += "#incident-" + *incident.Slug
+		}
+
+		response := StatuspageIncident{
+			ID:              incident.ID,
+			Name:            incident.Title,
+			Status:          status,
+			CreatedAt:       statuspageTime(incident.CreatedAt),
+			UpdatedAt:       statuspageTime(incident.UpdatedAt),
+			MonitoringAt:    statuspageOptionalTime(incident.MonitoringAt),
+			ResolvedAt:      statuspageOptionalTime(incident.ResolvedAt),
+			Impact:          incident.Impact,
+			Shortlink:       shortlink,
+			StartedAt:       statuspageTime(incident.CreatedAt),
+			PageID:          s.Org.ID,
+			IncidentUpdates: make([]StatuspageIncidentUpdate, 0, len(updates)),
+			Components:      s.visibleComponents(serviceIDs),
+		}
+
+		for _, update := range updates {
+			affected := []StatuspageAffectedComponent{}
+			for _, change := range update.ServiceChanges {
+				name, ok := serviceNames[change.ServiceID]
+				if !ok {
+					continue
+				}
+				affected = append(affected, StatuspageAffectedComponent{
+					Code:      change.ServiceID,
+					Name:      name,
+					OldStatus: statuspageComponentStatus(change.PreviousStatus),
+					NewStatus: statuspageComponentStatus(change.Status),
+				})
+			}
+
+			updateStatus := update.Status
+			if updateStatus == "" {
+				updateStatus = incident.Status
+			}
+
+			response.IncidentUpdates = append(response.IncidentUpdates, StatuspageIncidentUpdate{
+				ID:                 update.ID,
+				Status:             strings.ToLower(updateStatus),
+				Body:               update.Message,
+				IncidentID:         incident.ID,
+				CreatedAt:          statuspageTime(update.CreatedAt),
+				UpdatedAt:          statuspageTime(update.UpdatedAt),
+				DisplayAt:          statuspageTime(update.CreatedAt),
+				AffectedComponents: affected,
+			})
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// maintenances converts maintenances into Statuspage scheduled maintenances. Maintenances have
+// no updates of their own, so each carries a single update describing its current state.
+func (s statuspageSource) maintenances(maintenances []models.Maintenance) ([]StatuspageIncident, error) {
+	responses := make([]StatuspageIncident, 0, len(maintenances))
+	for _, maintenance := range maintenances {
+		status, ok := statuspageMaintenanceStatuses[maintenance.Status]
+		if !ok {
+			continue
+		}
+
+		var serviceIDs []string
+		if err := db.DB.Model(&models.MaintenanceService{}).
+			Where("maintenance_id = ?", maintenance.ID).
+			Pluck("service_id", &serviceIDs).Error; err != nil {
+			return nil, err
+		}
+
+		startedAt := maintenance.ScheduledStart
+		if maintenance.StartedAt != nil {
+			startedAt = *maintenance.StartedAt
+		}
+
+		responses = append(responses, StatuspageIncident{
+			ID:             maintenance.ID,
+			Name:           maintenance.Title,
+			Status:         status,
+			CreatedAt:      statuspageTime(maintenance.CreatedAt),
+			UpdatedAt:      statuspageTime(maintenance.UpdatedAt),
+			ResolvedAt:     statuspageOptionalTime(maintenance.CompletedAt),
+			Impact:         "maintenance",
+			Shortlink:      s.PageURL + "#maintenance-" + maintenance.ID,
+			StartedAt:      statuspageTime(startedAt),
+			PageID:         s.Org.ID,
+			Components:     s.visibleComponents(serviceIDs),
+			ScheduledFor:   statuspageOptionalTime(&maintenance.ScheduledStart),
+			ScheduledUntil: statuspageOptionalTime(&maintenance.ScheduledEnd),
+			IncidentUpdates: []StatuspageIncidentUpdate{{
+				ID:                 maintenance.ID,
+				Status:             status,
+				Body:               maintenance.Description,
+				IncidentID:         maintenance.ID,
+				CreatedAt:          statuspageTime(maintenance.UpdatedAt),
+				UpdatedAt:          statuspageTime(maintenance.UpdatedAt),
+				DisplayAt:          statuspageTime(maintenance.UpdatedAt),
+				AffectedComponents: []StatuspageAffectedComponent{},
+			}},
+		})
+	}
+
+	return responses, nil
+}
+
+// unresolvedIncidents returns the organization's open incidents, newest first
+func (s statuspageSource) unresolvedIncidents() ([]StatuspageIncident, error) {
+	var incidents []models.Incident
+	if err := db.DB.Where("org_id = ? AND status != ?", s.Org.ID, models.IncidentResolved).
+		Order("created_at DESC").
+		Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+	return s.incidents(incidents)
+}
+
+// upcomingMaintenances returns the organization's in-progress and scheduled maintenances, soonest first
+func (s statuspageSource) upcomingMaintenances() ([]StatuspageIncident, error) {
+	var maintenances []models.Maintenance
+	if err := db.DB.Where("org_id = ? AND status IN ?", s.Org.ID, []string{models.MaintenanceInProgress, models.MaintenanceScheduled}).
+		Order("scheduled_start ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, err
+	}
+	return s.maintenances(maintenances)
+}
+
+// GetStatuspageSummary returns the page status, components, unresolved incidents and upcoming
+// maintenances in the Statuspage v2 summary.json format
+func GetStatuspageSummary(c *gin.Context) {
+	source, ok := loadStatuspageSource(c)
+	if !ok {
+		return
+	}
+
+	incidents, err := source.unresolvedIncidents()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	maintenances, err := source.upcomingMaintenances()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":                   source.page(),
+		"status":                 source.status(),
+		"components":             source.components(),
+		"incidents":              incidents,
+		"scheduled_maintenances": maintenances,
+	})
+}
+
+// GetStatuspageStatus returns the page status in the Statuspage v2 status.json format
+func GetStatuspageStatus(c *gin.Context) {
+	source, ok := loadStatuspageSource(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":   source.page(),
+		"status": source.status(),
+	})
+}
+
+// GetStatuspageComponents returns the page components in the Statuspage v2 components.json format
+func GetStatuspageComponents(c *gin.Context) {
+	source, ok := loadStatuspageSource(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":       source.page(),
+		"components": source.components(),
+	})
+}
+
+// GetStatuspageIncidents returns the most recent incidents, resolved or not, in the Statuspage v2
+// incidents.json format
+func GetStatuspageIncidents(c *gin.Context) {
+	source, ok := loadStatuspageSource(c)
+	if !ok {
+		return
+	}
+
+	var incidents []models.Incident
+	if err := db.DB.Where("org_id = ?", source.Org.ID).
+		Order("created_at DESC").
+		Limit(maxStatuspageItems).
+		Find(&incidents).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	responses, err := source.incidents(incidents)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":      source.page(),
+		"incidents": responses,
+	})
+}
+
+// GetStatuspageScheduledMaintenances returns the most recent maintenances in the Statuspage v2
+// scheduled-maintenances.json format
+func GetStatuspageScheduledMaintenances(c *gin.Context) {
+	source, ok := loadStatuspageSource(c)
+	if !ok {
+		return
+	}
+
+	var maintenances []models.Maintenance
+	if err := db.DB.Where("org_id = ? AND status != ?", source.Org.ID, models.MaintenanceCancelled).
+		Order("scheduled_start DESC").
+		Limit(maxStatuspageItems).
+		Find(&maintenances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	responses, err := source.maintenances(maintenances)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":                   source.page(),
+		"scheduled_maintenances": responses,
+	})
+}
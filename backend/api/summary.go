@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// PublicPage identifies the organization a public status page belongs to
+type PublicPage struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SummaryResponse represents the overall state of an organization's status page
+type SummaryResponse struct {
+	Page         PublicPage                  `json:"page"`
+	Status       PageStatus                  `json:"status"`
+	Counts       map[string]int              `json:"counts"`
+	Incidents    []PublicIncidentResponse    `json:"incidents"`
+	Maintenances []PublicMaintenanceResponse `json:"maintenances"`
+}
+
+// countServicesByStatus counts services per status, listing every supported status
+func countServicesByStatus(services []models.Service) map[string]int {
+	counts := make(map[string]int, len(models.ServiceStatuses))
+	for _, status := range models.ServiceStatuses {
+		counts[status.Name] = 0
+	}
+
+	for _, service := range services {
+		if models.IsValidServiceStatus(service.Status) {
+			counts[service.Status]++
+		} else {
+			counts[models.StatusUnknown]++
+		}
+	}
+
+	return counts
+}
+
+// GetPublicSummary returns the overall page status, per-status service counts and
+// active incidents and in-progress maintenances for a public status page in a single response
+func GetPublicSummary(c *gin.Context) {
+	orgID := c.Param("orgId")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID is required"})
+		return
+	}
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return
+	}
+
+	services, _, err := loadPublicServices(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+		return
+	}
+
+	incidents, err := loadActivePublicIncidents(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+		return
+	}
+
+	maintenances, err := loadPublicMaintenances(org.ID, models.MaintenanceInProgress)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+		return
+	}
+
+	c.JSON(http.StatusOK, SummaryResponse{
+		Page: PublicPage{
+			ID:   org.ID,
+			Name: org.Name,
+		},
+		Status:       overallPageStatus(services),
+		Counts:       countServicesByStatus(services),
+		Incidents:    incidents,
+		Maintenances: maintenances,
+	})
+}
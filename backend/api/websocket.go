@@ -72,3 +72,8 @@ func BroadcastIncidentUpdated(orgID string, incident interface{}) {
 func BroadcastUpdateAdded(orgID string, update interface{}) {
 	WebsocketService.BroadcastToOrganization(orgID, services.UpdateAdded, update)
 }
+
+// BroadcastPostmortemPublished broadcasts a published postmortem to all clients
+func BroadcastPostmortemPublished(orgID string, postmortem interface{}) {
+	WebsocketService.BroadcastToOrganization(orgID, services.PostmortemPublished, postmortem)
+}
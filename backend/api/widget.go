@@ -0,0 +1,306 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/status_page/backend/db"
+	"github.com/status_page/backend/models"
+	"gorm.io/gorm"
+)
+
+// templateFS holds the HTML templates of server-rendered public pages
+//
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var widgetTemplate = template.Must(template.ParseFS(templateFS, "templates/widget.html"))
+
+// widgetMaxAge is how long, in seconds, clients and CDNs may cache the widget
+const widgetMaxAge = 60
+
+var widgetThemes = map[string]bool{"light": true, "dark": true}
+
+var widgetPositions = map[string]bool{"bottom-right": true, "bottom-left": true, "top-right": true, "top-left": true}
+
+// WidgetConfigRequest represents the request for configuring the status widget
+type WidgetConfigRequest struct {
+	Enabled          bool   `json:"enabled"`
+	Theme            string `json:"theme" binding:"required"`
+	Position         string `json:"position" binding:"required"`
+	ShowIncidents    bool   `json:"showIncidents"`
+	ShowMaintenances bool   `json:"showMaintenances"`
+	MaxItems         int    `json:"maxItems" binding:"min=1,max=10"`
+}
+
+// WidgetResponse is the compact status payload of the embeddable widget
+type WidgetResponse struct {
+	Page         WidgetPage          `json:"page"`
+	Status       WidgetStatus        `json:"status"`
+	Incidents    []WidgetIncident    `json:"incidents"`
+	Maintenances []WidgetMaintenance `json:"maintenances"`
+	Config       WidgetDisplay       `json:"config"`
+
+	// Only used by the HTML widget
+	RefreshSeconds int `json:"-"`
+}
+
+// WidgetPage identifies the status page the widget links to
+type WidgetPage struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// WidgetStatus is the overall page status with the color it is shown in
+type WidgetStatus struct {
+	Status      string `json:"status"`
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
+
+// WidgetIncident is an active incident as listed by the widget
+type WidgetIncident struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Impact    string `json:"impact"`
+	URL       string `json:"url"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// WidgetMaintenance is an in-progress or upcoming maintenance as listed by the widget
+type WidgetMaintenance struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	ScheduledStart string `json:"scheduledStart"`
+	ScheduledEnd   string `json:"scheduledEnd"`
+	URL            string `json:"url"`
+}
+
+// WidgetDisplay is the part of the widget configuration embedding sites need
+type WidgetDisplay struct {
+	Theme    string `json:"theme"`
+	Position string `json:"position"`
+}
+
+// loadWidgetConfig returns an organization's widget configuration, or the defaults when it
+// was never configured
+func loadWidgetConfig(orgID string) (models.WidgetConfig, error) {
+	config := models.WidgetConfig{
+		OrgID:            orgID,
+		Enabled:          true,
+		Theme:            "light",
+		Position:         "bottom-right",
+		ShowIncidents:    true,
+		ShowMaintenances: true,
+		MaxItems:         3,
+	}
+
+	err := db.DB.Where("org_id = ?", orgID).First(&config).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return config, err
+	}
+	return config, nil
+}
+
+// GetWidgetConfig returns the status widget configuration of the user's organization
+func GetWidgetConfig(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	config, err := loadWidgetConfig(orgID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve widget configuration"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"widget": config})
+}
+
+// UpdateWidgetConfig updates the status widget configuration of the user's organization
+func UpdateWidgetConfig(c *gin.Context) {
+	var req WidgetConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !widgetThemes[req.Theme] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid theme value"})
+		return
+	}
+
+	if !widgetPositions[req.Position] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid position value"})
+		return
+	}
+
+	orgID, _ := c.Get("org_id")
+
+	config, err := loadWidgetConfig(orgID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve widget configuration"})
+		return
+	}
+
+	config.Enabled = req.Enabled
+	config.Theme = req.Theme
+	config.Position = req.Position
+	config.ShowIncidents = req.ShowIncidents
+	config.ShowMaintenances = req.ShowMaintenances
+	config.MaxItems = req.MaxItems
+
+	// Save inserts the configuration the first time and updates it afterwards
+	if err := db.DB.Save(&config).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update widget configuration"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"widget": config})
+}
+
+// loadWidget builds the widget payload of an organization, writing the error response if it
+// cannot. Organizations that disabled the widget are reported as not found.
+func loadWidget(c *gin.Context) (WidgetResponse, bool) {
+	orgID := c.Param("orgId")
+
+	var org models.Organization
+	if err := db.DB.Where("id = ?", orgID).First(&org).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+		}
+		return WidgetResponse{}, false
+	}
+
+	config, err := loadWidgetConfig(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve widget configuration"})
+		return WidgetResponse{}, false
+	}
+
+	if !config.Enabled {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Widget is not enabled"})
+		return WidgetResponse{}, false
+	}
+
+	services, _, err := loadPublicServices(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve services"})
+		return WidgetResponse{}, false
+	}
+
+	pageURL := publicPageURL(c, org.ID)
+	status := models.WorstServiceStatus(aggregateServiceStatus(services))
+
+	response := WidgetResponse{
+		Page: WidgetPage{ID: org.ID, Name: org.Name, URL: pageURL},
+		Status: WidgetStatus{
+			Status:      status.Name,
+			Indicator:   status.Indicator,
+			Description: status.Description,
+			Color:       status.Color,
+		},
+		Incidents:      []WidgetIncident{},
+		Maintenances:   []WidgetMaintenance{},
+		Config:         WidgetDisplay{Theme: config.Theme, Position: config.Position},
+		RefreshSeconds: widgetMaxAge,
+	}
+
+	if config.ShowIncidents {
+		var incidents []models.Incident
+		if err := db.DB.Where("org_id = ? AND status != ?", org.ID, models.IncidentResolved).
+			Order("created_at DESC").
+			Limit(config.MaxItems).
+			Find(&incidents).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve incidents"})
+			return WidgetResponse{}, false
+		}
+
+		for _, incident := range incidents {
+			link := pageURL
+			if incident.Slug != nil {
+				link += "#incident-" + *incident.Slug
+			}
+			response.Incidents = append(response.Incidents, WidgetIncident{
+				ID:        incident.ID,
+				Title:     incident.Title,
+				Status:    incident.Status,
+				Impact:    incident.Impact,
+				URL:       link,
+				UpdatedAt: incident.UpdatedAt.UTC().Format(publicTimeFormat),
+			})
+		}
+	}
+
+	if config.ShowMaintenances {
+		var maintenances []models.Maintenance
+		if err := db.DB.Where("org_id = ? AND status IN ?", org.ID, []string{models.MaintenanceInProgress, models.MaintenanceScheduled}).
+			Order("scheduled_start ASC").
+			Limit(config.MaxItems).
+			Find(&maintenances).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve maintenances"})
+			return WidgetResponse{}, false
+		}
+
+		for _, maintenance := range maintenances {
+			response.Maintenances = append(response.Maintenances, WidgetMaintenance{
+				ID:             maintenance.ID,
+				Title:          maintenance.Title,
+				Status:         maintenance.Status,
+				ScheduledStart: maintenance.ScheduledStart.UTC().Format(publicTimeFormat),
+				ScheduledEnd:   maintenance.ScheduledEnd.UTC().Format(publicTimeFormat),
+				URL:            pageURL + "#maintenance-" + maintenance.ID,
+			})
+		}
+	}
+
+	return response, true
+}
+
+// setWidgetHeaders allows any site to fetch or embed the widget
+func setWidgetHeaders(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Cross-Origin-Resource-Policy", "cross-origin")
+}
+
+// GetPublicWidgetData returns the compact status payload of the embeddable widget
+func GetPublicWidgetData(c *gin.Context) {
+	widget, ok := loadWidget(c)
+	if !ok {
+		return
+	}
+
+	body, err := json.Marshal(widget)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate widget"})
+		return
+	}
+
+	setWidgetHeaders(c)
+	writeCached(c, "application/json; charset=utf-8", body, widgetMaxAge)
+}
+
+// GetPublicWidget returns the widget as a minimal HTML page meant to be embedded in an iframe
+func GetPublicWidget(c *gin.Context) {
+	widget, ok := loadWidget(c)
+	if !ok {
+		return
+	}
+
+	var body bytes.Buffer
+	if err := widgetTemplate.Execute(&body, widget); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate widget"})
+		return
+	}
+
+	setWidgetHeaders(c)
+	writeCached(c, "text/html; charset=utf-8", body.Bytes(), widgetMaxAge)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -25,7 +26,8 @@ func Connect() {
 	)
 
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Report constraint violations as gorm errors such as gorm.ErrDuplicatedKey
+	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -38,16 +40,96 @@ func MigrateDB() {
 	// --->>here<<--- Database migrations are performed with GORM
 	err := DB.AutoMigrate(
 		&models.Organization{},
+		&models.FooterLink{},
 		&models.User{},
+		&models.ServiceGroup{},
 		&models.Service{},
+		&models.ServiceDependency{},
 		&models.Incident{},
 		&models.IncidentUpdate{},
+		&models.IncidentUpdateServiceChange{},
+		&models.IncidentUpdateRevision{},
 		&models.IncidentService{},
+		&models.IncidentNote{},
+		&models.Postmortem{},
+		&models.IncidentTemplate{},
+		&models.IncidentTemplateService{},
+		&models.IncidentTemplateMessage{},
+		&models.Maintenance{},
+		&models.MaintenanceService{},
+		&models.MaintenanceSeries{},
+		&models.MaintenanceSeriesService{},
+		&models.WidgetConfig{},
+		&models.CustomDomain{},
 	)
 
 	if err != nil {
 		log.Fatalf("Failed to run database migrations: %v", err)
 	}
 
+	if err := backfillIncidentSlugs(); err != nil {
+		log.Fatalf("Failed to backfill incident slugs: %v", err)
+	}
+
+	if err := backfillIncidentResolvedAt(); err != nil {
+		log.Fatalf("Failed to backfill incident resolution times: %v", err)
+	}
+
 	log.Println("Database migrations completed")
 }
+
+// backfillIncidentSlugs gives a slug to incidents created before slugs existed
+func backfillIncidentSlugs() error {
+	var incidentIDs []string
+	if err := DB.Unscoped().Model(&models.Incident{}).
+		Where("slug IS NULL").
+		Pluck("id", &incidentIDs).Error; err != nil {
+		return err
+	}
+
+	for _, incidentID := range incidentIDs {
+		if err := DB.Unscoped().Model(&models.Incident{}).
+			Where("id = ?", incidentID).
+			Update("slug", utils.GenerateSlug()).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillIncidentResolvedAt sets the resolution time of incidents resolved before it was recorded,
+// taken from the update that resolved them or, failing that, their last change
+func backfillIncidentResolvedAt() error {
+	var incidents []models.Incident
+	if err := DB.Unscoped().
+		Where("status = ? AND resolved_at IS NULL", models.IncidentResolved).
+		Find(&incidents).Error; err != nil {
+		return err
+	}
+
+	for _, incident := range incidents {
+		resolvedAt := incident.UpdatedAt
+
+		var update models.IncidentUpdate
+		err := DB.Unscoped().
+			Where("incident_id = ? AND status = ?", incident.ID, models.IncidentResolved).
+			Order("created_at DESC").
+			First(&update).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if err == nil {
+			resolvedAt = update.CreatedAt
+		}
+
+		// UpdateColumn leaves UpdatedAt alone, so feeds do not report the incident as changed
+		if err := DB.Unscoped().Model(&models.Incident{}).
+			Where("id = ?", incident.ID).
+			UpdateColumn("resolved_at", resolvedAt).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"log"
+	"net/http"
 	"os"
+	"time"
+	_ "time/tzdata" // Time zones of organizations must load without system tzdata
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -10,6 +13,7 @@ import (
 	"github.com/status_page/backend/api"
 	"github.com/status_page/backend/db"
 	"github.com/status_page/backend/middleware"
+	"github.com/status_page/backend/services"
 )
 
 func main() {
@@ -28,6 +32,9 @@ func main() {
 	db.Connect()
 	db.MigrateDB()
 
+	// Start and complete scheduled maintenances at their scheduled times
+	services.NewMaintenanceScheduler(db.DB, api.WebsocketService, 30*time.Second).Start()
+
 	// Initialize Gin router
 	r := gin.Default()
 
@@ -40,6 +47,9 @@ func main() {
 		AllowCredentials: true,
 	}))
 
+	// Server-rendered public status page
+	r.GET("/status/:orgId", api.GetStatusPage)
+
 	// Public routes
 	public := r.Group("/api")
 	{
@@ -47,9 +57,32 @@ func main() {
 		public.POST("/auth/signup", api.Signup)
 		public.POST("/auth/login", api.Login)
 
+		// Supported service statuses
+		public.GET("/statuses", api.GetStatuses)
+
 		// Public status page routes - no authentication required
+		public.GET("/public/:orgId/config", api.GetPublicPageConfig)
 		public.GET("/public/:orgId/services", api.GetPublicServices)
 		public.GET("/public/:orgId/incidents", api.GetPublicIncidents)
+		public.GET("/public/:orgId/incidents/:id", api.GetPublicIncident)
+		public.GET("/public/:orgId/incidents/:id/postmortem", api.GetPublicPostmortem)
+		public.GET("/public/:orgId/history", api.GetPublicHistory)
+		public.GET("/public/:orgId/feed.rss", api.GetPublicRSSFeed)
+		public.GET("/public/:orgId/feed.atom", api.GetPublicAtomFeed)
+		public.GET("/public/:orgId/summary", api.GetPublicSummary)
+		public.GET("/public/:orgId/maintenances", api.GetPublicMaintenances)
+		public.GET("/public/:orgId/maintenances.ics", api.GetPublicMaintenanceCalendar)
+		public.GET("/public/:orgId/badge.svg", api.GetPublicBadge)
+		public.GET("/public/:orgId/services/:serviceId/badge.svg", api.GetPublicServiceBadge)
+		public.GET("/public/:orgId/widget.json", api.GetPublicWidgetData)
+		public.GET("/public/:orgId/widget", api.GetPublicWidget)
+
+		// Statuspage-compatible public API
+		public.GET("/public/:orgId/api/v2/summary.json", api.GetStatuspageSummary)
+		public.GET("/public/:orgId/api/v2/status.json", api.GetStatuspageStatus)
+		public.GET("/public/:orgId/api/v2/components.json", api.GetStatuspageComponents)
+		public.GET("/public/:orgId/api/v2/incidents.json", api.GetStatuspageIncidents)
+		public.GET("/public/:orgId/api/v2/scheduled-maintenances.json", api.GetStatuspageScheduledMaintenances)
 
 		// WebSocket connection for real-time updates
 		public.GET("/ws/:orgId", api.HandleWebSocket)
@@ -66,18 +99,82 @@ func main() {
 		protected.PUT("/services/:id", api.UpdateService)
 		protected.DELETE("/services/:id", api.DeleteService)
 
+		// Service dependencies
+		protected.GET("/services/:id/dependencies", api.GetServiceDependencies)
+		protected.POST("/services/:id/dependencies", api.AddServiceDependency)
+		protected.DELETE("/services/:id/dependencies/:dependsOnId", api.RemoveServiceDependency)
+
+		// Service group management
+		protected.GET("/service-groups", api.GetServiceGroups)
+		protected.GET("/service-groups/:id", api.GetServiceGroup)
+		protected.POST("/service-groups", api.CreateServiceGroup)
+		protected.PUT("/service-groups/:id", api.UpdateServiceGroup)
+		protected.DELETE("/service-groups/:id", api.DeleteServiceGroup)
+
 		// Incident management
 		protected.GET("/incidents", api.GetIncidents)
 		protected.GET("/incidents/:id", api.GetIncident)
 		protected.POST("/incidents", api.CreateIncident)
 		protected.PUT("/incidents/:id", api.UpdateIncident)
 		protected.DELETE("/incidents/:id", api.DeleteIncident)
+		protected.POST("/incidents/:id/reopen", api.ReopenIncident)
 
 		// Incident updates
 		protected.POST("/incidents/:id/updates", api.AddIncidentUpdate)
+		protected.PUT("/incidents/:id/updates/:updateId", api.EditIncidentUpdate)
+		protected.DELETE("/incidents/:id/updates/:updateId", api.DeleteIncidentUpdate)
+		protected.GET("/incidents/:id/updates/:updateId/revisions", api.GetIncidentUpdateRevisions)
+
+		// Internal incident notes
+		protected.GET("/incidents/:id/notes", api.GetIncidentNotes)
+		protected.POST("/incidents/:id/notes", api.AddIncidentNote)
+		protected.DELETE("/incidents/:id/notes/:noteId", api.DeleteIncidentNote)
+
+		// Incident postmortems
+		protected.GET("/incidents/:id/postmortem", api.GetPostmortem)
+		protected.PUT("/incidents/:id/postmortem", api.SavePostmortem)
+		protected.POST("/incidents/:id/postmortem/publish", api.PublishPostmortem)
+		protected.DELETE("/incidents/:id/postmortem", api.DeletePostmortem)
+
+		// Incident template management
+		protected.GET("/incident-templates", api.GetIncidentTemplates)
+		protected.GET("/incident-templates/:id", api.GetIncidentTemplate)
+		protected.POST("/incident-templates", api.CreateIncidentTemplate)
+		protected.PUT("/incident-templates/:id", api.UpdateIncidentTemplate)
+		protected.DELETE("/incident-templates/:id", api.DeleteIncidentTemplate)
+
+		// Scheduled maintenance management
+		protected.GET("/maintenances", api.GetMaintenances)
+		protected.GET("/maintenances/:id", api.GetMaintenance)
+		protected.POST("/maintenances", api.CreateMaintenance)
+		protected.PUT("/maintenances/:id", api.UpdateMaintenance)
+		protected.POST("/maintenances/:id/cancel", api.CancelMaintenance)
+		protected.POST("/maintenances/:id/complete", api.CompleteMaintenance)
+		protected.DELETE("/maintenances/:id", api.DeleteMaintenance)
+
+		// Recurring maintenance management
+		protected.GET("/maintenance-series", api.GetMaintenanceSeriesList)
+		protected.GET("/maintenance-series/:id", api.GetMaintenanceSeries)
+		protected.POST("/maintenance-series", api.CreateMaintenanceSeries)
+		protected.PUT("/maintenance-series/:id", api.UpdateMaintenanceSeries)
+		protected.DELETE("/maintenance-series/:id", api.DeleteMaintenanceSeries)
+
+		// Status page settings
+		protected.GET("/settings", api.GetSettings)
+		protected.PUT("/settings", middleware.RequireAdmin(), api.UpdateSettings)
+		protected.GET("/settings/widget", api.GetWidgetConfig)
+		protected.PUT("/settings/widget", middleware.RequireAdmin(), api.UpdateWidgetConfig)
+
+		// Custom domain management
+		protected.GET("/settings/domains", api.GetCustomDomains)
+		protected.POST("/settings/domains", middleware.RequireAdmin(), api.AddCustomDomain)
+		protected.POST("/settings/domains/:id/verify", middleware.RequireAdmin(), api.VerifyCustomDomain)
+		protected.DELETE("/settings/domains/:id", middleware.RequireAdmin(), api.DeleteCustomDomain)
 	}
 
-	// Start the server
+	// Start the server, serving verified custom domains their organization's public pages
 	log.Printf("Server running on port %s", port)
-	r.Run(":" + port)
+	if err := http.ListenAndServe(":"+port, api.CustomDomainRouter(r)); err != nil {
+		log.Fatalf("Server stopped: %v", err)
+	}
 }
@@ -15,6 +15,25 @@ type Organization struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 	Users     []User         `gorm:"foreignKey:OrgID"`
 	Services  []Service      `gorm:"foreignKey:OrgID"`
+
+	// Branding of the public status page
+	LogoURL         string
+	FaviconURL      string
+	PrimaryColor    string // Hex color of the page header, e.g. #1f6feb
+	HeaderTextColor string // Hex color of the text on the page header
+	HeaderText      string // Shown under the organization name
+	SupportURL      string
+	Timezone        string `gorm:"not null;default:'UTC'"` // IANA time zone dates are displayed in
+	About           string `gorm:"type:text"`
+}
+
+// FooterLink represents a link shown in the footer of an organization's public status page
+type FooterLink struct {
+	ID           string `gorm:"primaryKey"`
+	OrgID        string `gorm:"not null;index"`
+	Label        string `gorm:"not null"`
+	URL          string `gorm:"not null"`
+	DisplayOrder int    `gorm:"not null;default:0"`
 }
 
 // User represents a user in the system
@@ -29,15 +48,45 @@ type User struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// ServiceGroup represents a named collection of services shown together on the status page
+type ServiceGroup struct {
+	ID           string `gorm:"primaryKey"`
+	Name         string `gorm:"not null"`
+	DisplayOrder int    `gorm:"not null;default:0"`
+	Collapsed    bool   `gorm:"not null;default:false"` // Collapsed by default on the public page
+	OrgID        string `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
 // Service represents a service that is monitored
 type Service struct {
-	ID        string `gorm:"primaryKey"`
-	Name      string `gorm:"not null"`
-	Status    string `gorm:"not null"` // Operational, Degraded, Outage
-	OrgID     string `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID           string `gorm:"primaryKey"`
+	Name         string `gorm:"not null"`
+	Description  string
+	HelpURL      string  // Public help/docs link
+	Status       string  `gorm:"not null"` // One of ServiceStatuses
+	OrgID        string  `gorm:"not null"`
+	GroupID      *string `gorm:"index"`
+	DisplayOrder int     `gorm:"not null;default:0"`
+	Hidden       bool    `gorm:"not null;default:false"` // Internal-only, never shown on the public page
+	ShowUptime   bool    `gorm:"not null;default:false"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+
+	// Computed from upstream dependencies, never persisted
+	ImpactedStatus string   `gorm:"-"` // Worst status among upstream services, empty when unaffected
+	ImpactedBy     []string `gorm:"-"` // IDs of the upstream services causing the impact
+}
+
+// ServiceDependency represents a service depending on another service of the same organization
+type ServiceDependency struct {
+	ServiceID   string `gorm:"primaryKey"`
+	DependsOnID string `gorm:"primaryKey;index"`
+	OrgID       string `gorm:"not null;index"`
+	CreatedAt   time.Time
 }
 
 // Incident represents an incident affecting one or more services
@@ -45,27 +94,204 @@ type Incident struct {
 	ID          string `gorm:"primaryKey"`
 	Title       string `gorm:"not null"`
 	Description string
-	Status      string `gorm:"not null"` // Investigating, Identified, Monitoring, Resolved
-	OrgID       string `gorm:"not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt   `gorm:"index"`
-	Updates     []IncidentUpdate `gorm:"foreignKey:IncidentID"`
+	Status      string  `gorm:"not null"`                // Investigating, Identified, Monitoring, Resolved
+	Impact      string  `gorm:"not null;default:'none'"` // none, minor, major, critical
+	Slug        *string `gorm:"uniqueIndex"`             // Short identifier for public links
+	OrgID       string  `gorm:"not null"`
+
+	// Set affected service statuses from their impact while the incident is open
+	AutoUpdateServices bool `gorm:"not null;default:false"`
+
+	// Lifecycle timestamps, set when the incident first reaches each status
+	IdentifiedAt *time.Time
+	MonitoringAt *time.Time
+	ResolvedAt   *time.Time
+
+	ReopenedAt   *time.Time
+	ReopenedByID string // User who last reopened the incident
+	ReopenCount  int    `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt   `gorm:"index"`
+	Updates   []IncidentUpdate `gorm:"foreignKey:IncidentID"`
 }
 
 // IncidentUpdate represents an update to an incident
 type IncidentUpdate struct {
-	ID         string `gorm:"primaryKey"`
-	Message    string `gorm:"not null"`
-	IncidentID string `gorm:"not null"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID             string     `gorm:"primaryKey"`
+	Message        string     `gorm:"not null"`
+	Status         string     // Incident status as of this update
+	IncidentID     string     `gorm:"not null"`
+	AuthorID       string     // User who posted the update
+	EditedAt       *time.Time // Set when the message was last edited
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt                `gorm:"index"`
+	ServiceChanges []IncidentUpdateServiceChange `gorm:"foreignKey:IncidentUpdateID"`
+}
+
+// IncidentUpdateRevision represents a previous version of an edited incident update message
+type IncidentUpdateRevision struct {
+	ID               string `gorm:"primaryKey"`
+	IncidentUpdateID string `gorm:"not null;index"`
+	PreviousMessage  string `gorm:"not null"`
+	EditorID         string `gorm:"not null"` // User who made the edit
+	CreatedAt        time.Time
+}
+
+// IncidentUpdateServiceChange represents a service status change applied with an incident update
+type IncidentUpdateServiceChange struct {
+	ID               string `gorm:"primaryKey"`
+	IncidentUpdateID string `gorm:"not null;index"`
+	ServiceID        string `gorm:"not null"`
+	PreviousStatus   string `gorm:"not null"`
+	Status           string `gorm:"not null"`
+	CreatedAt        time.Time
 }
 
 // IncidentService represents the many-to-many relationship between incidents and services
 type IncidentService struct {
-	IncidentID string `gorm:"primaryKey"`
+	IncidentID     string `gorm:"primaryKey"`
+	ServiceID      string `gorm:"primaryKey"`
+	Impact         string `gorm:"not null;default:'none'"` // Impact of the incident on this service
+	PreviousStatus string // Service status before it was affected by the incident
+}
+
+// IncidentNote represents an internal note on an incident, visible only to organization members
+type IncidentNote struct {
+	ID          string `gorm:"primaryKey"`
+	IncidentID  string `gorm:"not null;index"`
+	AuthorID    string `gorm:"not null"`
+	AuthorEmail string // Email of the author when the note was written
+	Body        string `gorm:"type:text;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Postmortem represents the retrospective document of a resolved incident
+type Postmortem struct {
+	ID              string `gorm:"primaryKey"`
+	IncidentID      string `gorm:"not null;uniqueIndex"`
+	OrgID           string `gorm:"not null;index"`
+	Body            string `gorm:"type:text"`                // Markdown
+	Status          string `gorm:"not null;default:'draft'"` // draft, published
+	PublishToPublic bool   `gorm:"not null;default:false"`   // Shown on the public page once published
+	PublishedAt     *time.Time
+	AuthorID        string // User who last edited the postmortem
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// IncidentTemplate represents a reusable starting point for a common failure scenario
+type IncidentTemplate struct {
+	ID          string `gorm:"primaryKey"`
+	Name        string `gorm:"not null"`
+	Title       string `gorm:"not null"` // May contain placeholders such as {{service}}
+	Description string
+	Status      string `gorm:"not null"` // Initial incident status
+	Impact      string `gorm:"not null;default:'none'"`
+	OrgID       string `gorm:"not null;index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt            `gorm:"index"`
+	Messages    []IncidentTemplateMessage `gorm:"foreignKey:TemplateID"`
+}
+
+// IncidentTemplateService represents a service affected by default by incidents created from a template
+type IncidentTemplateService struct {
+	TemplateID string `gorm:"primaryKey"`
 	ServiceID  string `gorm:"primaryKey"`
 }
 
+// IncidentTemplateMessage represents a canned incident update message of a template
+type IncidentTemplateMessage struct {
+	ID           string `gorm:"primaryKey"`
+	TemplateID   string `gorm:"not null;index"`
+	Name         string `gorm:"not null"`
+	Message      string `gorm:"not null"` // May contain placeholders such as {{service}}
+	Status       string // Incident status applied with the update, if any
+	DisplayOrder int    `gorm:"not null;default:0"`
+}
+
+// WidgetConfig represents how an organization's embeddable status widget is shown. The flags have
+// no database defaults, since gorm would store those in place of false when the row is created;
+// loadWidgetConfig supplies the defaults instead.
+type WidgetConfig struct {
+	OrgID            string `gorm:"primaryKey"`
+	Enabled          bool   `gorm:"not null"`
+	Theme            string `gorm:"not null;default:'light'"`        // light, dark
+	Position         string `gorm:"not null;default:'bottom-right'"` // bottom-right, bottom-left, top-right, top-left
+	ShowIncidents    bool   `gorm:"not null"`
+	ShowMaintenances bool   `gorm:"not null"`
+	MaxItems         int    `gorm:"not null;default:3"` // Incidents and maintenances listed at most
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// CustomDomain represents a hostname under which an organization's public status page is served.
+// Only domains verified through a DNS TXT record are routed to the organization, and a hostname
+// can be verified by one organization at most.
+type CustomDomain struct {
+	ID                string `gorm:"primaryKey"`
+	OrgID             string `gorm:"not null;uniqueIndex:idx_custom_domain_hostname"`
+	Hostname          string `gorm:"not null;uniqueIndex:idx_custom_domain_hostname;uniqueIndex:idx_custom_domain_verified_hostname,where:verified_at IS NOT NULL"` // Lowercase, without port
+	VerificationToken string `gorm:"not null"`
+	VerifiedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Maintenance represents a scheduled maintenance window affecting one or more services
+type Maintenance struct {
+	ID             string `gorm:"primaryKey"`
+	Title          string `gorm:"not null"`
+	Description    string
+	Status         string    `gorm:"not null;index"` // Scheduled, In Progress, Completed, Cancelled
+	ScheduledStart time.Time `gorm:"not null;index"`
+	ScheduledEnd   time.Time `gorm:"not null"`
+	StartedAt      *time.Time
+	CompletedAt    *time.Time
+	OrgID          string `gorm:"not null;index"`
+
+	// Set when this maintenance is an occurrence of a recurring series
+	SeriesID        *string    `gorm:"uniqueIndex:idx_maintenance_occurrence"`
+	OccurrenceStart *time.Time `gorm:"uniqueIndex:idx_maintenance_occurrence"` // Start given by the recurrence rule
+	Detached        bool       `gorm:"not null;default:false"`                 // Edited individually, no longer follows the series
+
+	Sequence int `gorm:"not null;default:0"` // Revision number for calendar feeds, increased by each change to the window
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// MaintenanceSeries represents a recurring maintenance expanded into individual maintenances
+type MaintenanceSeries struct {
+	ID              string `gorm:"primaryKey"`
+	Title           string `gorm:"not null"`
+	Description     string
+	RecurrenceRule  string    `gorm:"not null"` // RFC 5545 RRULE, e.g. FREQ=MONTHLY;BYDAY=2TU
+	StartsAt        time.Time `gorm:"not null"` // Start of the first occurrence
+	DurationMinutes int       `gorm:"not null"`
+	OrgID           string    `gorm:"not null;index"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// MaintenanceSeriesService represents the many-to-many relationship between maintenance series and services
+type MaintenanceSeriesService struct {
+	SeriesID  string `gorm:"primaryKey"`
+	ServiceID string `gorm:"primaryKey"`
+}
+
+// MaintenanceService represents the many-to-many relationship between maintenances and services
+type MaintenanceService struct {
+	MaintenanceID  string `gorm:"primaryKey"`
+	ServiceID      string `gorm:"primaryKey"`
+	PreviousStatus string // Service status before the window started, restored once it ends
+}
+
 // --->>here<<--- This is where the database models are defined for PostgreSQL integration with GORM
@@ -0,0 +1,157 @@
+package models
+
+// Service status values
+const (
+	StatusOperational      = "Operational"
+	StatusUnderMaintenance = "Under Maintenance"
+	StatusUnknown          = "Unknown"
+	StatusDegraded         = "Degraded"
+	StatusPartialOutage    = "Partial Outage"
+	StatusOutage           = "Outage"
+)
+
+// ServiceStatus describes a service status, how severe it is and how it is summarized
+// when it is the worst status on a page
+type ServiceStatus struct {
+	Name        string `json:"name"`
+	Severity    int    `json:"severity"`
+	Indicator   string `json:"indicator"`   // none, maintenance, minor, major, critical
+	Description string `json:"description"` // Page-level summary, e.g. "All Systems Operational"
+	Code        string `json:"code"`        // Statuspage-compatible component status
+	Color       string `json:"color"`       // Hex color used for badges
+}
+
+// ServiceStatuses lists every supported service status ordered from least to most severe.
+// Adding a status here makes it valid everywhere statuses are validated or aggregated.
+var ServiceStatuses = []ServiceStatus{
+	{Name: StatusOperational, Severity: 0, Indicator: "none", Description: "All Systems Operational", Code: "operational", Color: "#44cc11"},
+	{Name: StatusUnderMaintenance, Severity: 1, Indicator: "maintenance", Description: "Service Under Maintenance", Code: "under_maintenance", Color: "#007ec6"},
+	{Name: StatusUnknown, Severity: 2, Indicator: "minor", Description: "Service Status Unknown", Code: "degraded_performance", Color: "#9f9f9f"},
+	{Name: StatusDegraded, Severity: 3, Indicator: "minor", Description: "Degraded Performance", Code: "degraded_performance", Color: "#dfb317"},
+	{Name: StatusPartialOutage, Severity: 4, Indicator: "major", Description: "Partial System Outage", Code: "partial_outage", Color: "#fe7d37"},
+	{Name: StatusOutage, Severity: 5, Indicator: "critical", Description: "Major System Outage", Code: "major_outage", Color: "#e05d44"},
+}
+
+// LookupServiceStatus returns the definition of a service status by name
+func LookupServiceStatus(name string) (ServiceStatus, bool) {
+	for _, status := range ServiceStatuses {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return ServiceStatus{}, false
+}
+
+// IsValidServiceStatus reports whether name is a supported service status
+func IsValidServiceStatus(name string) bool {
+	_, ok := LookupServiceStatus(name)
+	return ok
+}
+
+// WorstServiceStatus returns the most severe of the given statuses, treating unrecognized
+// values as Unknown and an empty list as Operational
+func WorstServiceStatus(statuses ...string) ServiceStatus {
+	worst, _ := LookupServiceStatus(StatusOperational)
+	for _, name := range statuses {
+		status, ok := LookupServiceStatus(name)
+		if !ok {
+			status, _ = LookupServiceStatus(StatusUnknown)
+		}
+		if status.Severity > worst.Severity {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// Incident status values
+const (
+	IncidentInvestigating = "Investigating"
+	IncidentIdentified    = "Identified"
+	IncidentMonitoring    = "Monitoring"
+	IncidentResolved      = "Resolved"
+)
+
+// IncidentStatuses lists every supported incident status in lifecycle order
+var IncidentStatuses = []string{
+	IncidentInvestigating,
+	IncidentIdentified,
+	IncidentMonitoring,
+	IncidentResolved,
+}
+
+// IsValidIncidentStatus reports whether name is a supported incident status
+func IsValidIncidentStatus(name string) bool {
+	for _, status := range IncidentStatuses {
+		if status == name {
+			return true
+		}
+	}
+	return false
+}
+
+// incidentTransitions lists the statuses each incident status may move to. Resolved incidents
+// can only be brought back through an explicit reopen.
+var incidentTransitions = map[string][]string{
+	IncidentInvestigating: {IncidentIdentified, IncidentMonitoring, IncidentResolved},
+	IncidentIdentified:    {IncidentInvestigating, IncidentMonitoring, IncidentResolved},
+	IncidentMonitoring:    {IncidentInvestigating, IncidentIdentified, IncidentResolved},
+	IncidentResolved:      {},
+}
+
+// CanTransitionIncident reports whether an incident may move from one status to another
+func CanTransitionIncident(from, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, status := range incidentTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Incident impact levels
+const (
+	ImpactNone     = "none"
+	ImpactMinor    = "minor"
+	ImpactMajor    = "major"
+	ImpactCritical = "critical"
+)
+
+// impactServiceStatuses maps each impact level to the service status it implies
+var impactServiceStatuses = map[string]string{
+	ImpactNone:     StatusOperational,
+	ImpactMinor:    StatusDegraded,
+	ImpactMajor:    StatusPartialOutage,
+	ImpactCritical: StatusOutage,
+}
+
+// IsValidImpact reports whether name is a supported impact level
+func IsValidImpact(name string) bool {
+	_, ok := impactServiceStatuses[name]
+	return ok
+}
+
+// ImpactServiceStatus returns the service status implied by an impact level
+func ImpactServiceStatus(impact string) string {
+	if status, ok := impactServiceStatuses[impact]; ok {
+		return status
+	}
+	return StatusUnknown
+}
+
+// Maintenance status values
+const (
+	MaintenanceScheduled  = "Scheduled"
+	MaintenanceInProgress = "In Progress"
+	MaintenanceCompleted  = "Completed"
+	MaintenanceCancelled  = "Cancelled"
+)
+
+// Postmortem status values
+const (
+	PostmortemDraft     = "draft"
+	PostmortemPublished = "published"
+)
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/status_page/backend/models"
+)
+
+// DependencyGraph maps a service ID to the IDs of the services it depends on
+type DependencyGraph map[string][]string
+
+// NewDependencyGraph builds a dependency graph from dependency records
+func NewDependencyGraph(dependencies []models.ServiceDependency) DependencyGraph {
+	graph := make(DependencyGraph)
+	for _, dependency := range dependencies {
+		graph[dependency.ServiceID] = append(graph[dependency.ServiceID], dependency.DependsOnID)
+	}
+	return graph
+}
+
+// Upstream returns the IDs of every service the given service depends on, directly or transitively
+func (g DependencyGraph) Upstream(serviceID string) []string {
+	visited := map[string]bool{serviceID: true}
+	queue := []string{serviceID}
+	var upstream []string
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g[current] {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			upstream = append(upstream, next)
+			queue = append(queue, next)
+		}
+	}
+
+	sort.Strings(upstream)
+	return upstream
+}
+
+// WouldCreateCycle reports whether adding a dependency from serviceID on dependsOnID
+// would make a service (transitively) depend on itself
+func (g DependencyGraph) WouldCreateCycle(serviceID, dependsOnID string) bool {
+	if serviceID == dependsOnID {
+		return true
+	}
+
+	for _, id := range g.Upstream(dependsOnID) {
+		if id == serviceID {
+			return true
+		}
+	}
+	return false
+}
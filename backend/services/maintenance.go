@@ -0,0 +1,454 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/status_page/backend/models"
+	"github.com/status_page/backend/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaintenanceSeriesHorizon is how far ahead recurring maintenances are expanded into occurrences
+const MaintenanceSeriesHorizon = 30 * 24 * time.Hour
+
+// StartMaintenance marks a maintenance as in progress and puts its services under maintenance,
+// remembering each service's previous status so it can be restored afterwards
+func StartMaintenance(tx *gorm.DB, maintenance *models.Maintenance, now time.Time) error {
+	var links []models.MaintenanceService
+	if err := tx.Where("maintenance_id = ?", maintenance.ID).Find(&links).Error; err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		var service models.Service
+		if err := tx.Where("id = ?", link.ServiceID).First(&service).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return err
+		}
+
+		previous, err := preMaintenanceStatus(tx, maintenance.ID, service)
+		if err != nil {
+			return err
+		}
+
+		link.PreviousStatus = previous
+		if err := tx.Save(&link).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&service).Update("status", models.StatusUnderMaintenance).Error; err != nil {
+			return err
+		}
+	}
+
+	maintenance.Status = models.MaintenanceInProgress
+	maintenance.StartedAt = &now
+	return tx.Save(maintenance).Error
+}
+
+// preMaintenanceStatus returns the status a service returns to once a maintenance ends. A service
+// already under an overlapping maintenance keeps the status recorded by that maintenance.
+func preMaintenanceStatus(tx *gorm.DB, maintenanceID string, service models.Service) (string, error) {
+	if service.Status != models.StatusUnderMaintenance {
+		return service.Status, nil
+	}
+
+	var overlapping models.MaintenanceService
+	err := tx.Joins("JOIN maintenances ON maintenances.id = maintenance_services.maintenance_id").
+		Where("maintenance_services.service_id = ? AND maintenances.id != ? AND maintenances.status = ? AND maintenances.deleted_at IS NULL AND maintenance_services.previous_status != ''",
+			service.ID, maintenanceID, models.MaintenanceInProgress).
+		Take(&overlapping).Error
+	if err == gorm.ErrRecordNotFound {
+		return service.Status, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return overlapping.PreviousStatus, nil
+}
+
+// EndMaintenance closes a maintenance with the given final status and restores the previous
+// status of its services, unless they were changed meanwhile or another in-progress
+// maintenance still covers them
+func EndMaintenance(tx *gorm.DB, maintenance *models.Maintenance, status string, now time.Time) error {
+	var links []models.MaintenanceService
+	if err := tx.Where("maintenance_id = ?", maintenance.ID).Find(&links).Error; err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		// Services are only touched if the window actually started
+		if link.PreviousStatus == "" {
+			continue
+		}
+
+		var service models.Service
+		if err := tx.Where("id = ?", link.ServiceID).First(&service).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return err
+		}
+
+		if service.Status != models.StatusUnderMaintenance {
+			continue
+		}
+
+		var overlapping int64
+		if err := tx.Model(&models.MaintenanceService{}).
+			Joins("JOIN maintenances ON maintenances.id = maintenance_services.maintenance_id").
+			Where("maintenance_services.service_id = ? AND maintenances.id != ? AND maintenances.status = ? AND maintenances.deleted_at IS NULL",
+				service.ID, maintenance.ID, models.MaintenanceInProgress).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+
+		if overlapping > 0 {
+			continue
+		}
+
+		restored := link.PreviousStatus
+		if restored == models.StatusUnderMaintenance {
+			restored = models.StatusOperational
+		}
+
+		if err := tx.Model(&service).Update("status", restored).Error; err != nil {
+			return err
+		}
+	}
+
+	maintenance.Status = status
+	maintenance.CompletedAt = &now
+	return tx.Save(maintenance).Error
+}
+
+// ExpandMaintenanceSeries creates the occurrences of a series that start within the horizon and
+// do not exist yet. Occurrences that were cancelled, edited or deleted are never recreated.
+func ExpandMaintenanceSeries(tx *gorm.DB, series *models.MaintenanceSeries, now time.Time) ([]models.Maintenance, error) {
+	rule, err := ParseRecurrenceRule(series.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	starts := rule.Between(series.StartsAt.UTC(), now, now.Add(MaintenanceSeriesHorizon))
+	if len(starts) == 0 {
+		return nil, nil
+	}
+
+	var existingStarts []time.Time
+	if err := tx.Unscoped().Model(&models.Maintenance{}).
+		Where("series_id = ?", series.ID).
+		Pluck("occurrence_start", &existingStarts).Error; err != nil {
+		return nil, err
+	}
+
+	existing := make(map[int64]bool, len(existingStarts))
+	for _, start := range existingStarts {
+		existing[start.Unix()] = true
+	}
+
+	var serviceIDs []string
+	if err := tx.Model(&models.MaintenanceSeriesService{}).
+		Where("series_id = ? AND service_id IN (?)", series.ID, tx.Model(&models.Service{}).Select("id")).
+		Pluck("service_id", &serviceIDs).Error; err != nil {
+		return nil, err
+	}
+
+	var created []models.Maintenance
+	for _, start := range starts {
+		if existing[start.Unix()] {
+			continue
+		}
+
+		occurrenceStart := start
+		maintenance := models.Maintenance{
+			ID:              utils.GenerateUUID(),
+			Title:           series.Title,
+			Description:     series.Description,
+			Status:          models.MaintenanceScheduled,
+			ScheduledStart:  start,
+			ScheduledEnd:    start.Add(time.Duration(series.DurationMinutes) * time.Minute),
+			OrgID:           series.OrgID,
+			SeriesID:        &series.ID,
+			OccurrenceStart: &occurrenceStart,
+		}
+
+		if err := tx.Create(&maintenance).Error; err != nil {
+			return nil, err
+		}
+
+		for _, serviceID := range serviceIDs {
+			link := models.MaintenanceService{
+				MaintenanceID: maintenance.ID,
+				ServiceID:     serviceID,
+			}
+			if err := tx.Create(&link).Error; err != nil {
+				return nil, err
+			}
+		}
+
+		created = append(created, maintenance)
+	}
+
+	return created, nil
+}
+
+// RescheduleMaintenanceSeries brings the upcoming occurrences of an edited series in line with it.
+// Occurrences are moved in place rather than recreated, so calendar subscribers see the same
+// event with a higher SEQUENCE; occurrences the new schedule no longer has are deleted, and
+// missing ones are created by ExpandMaintenanceSeries. Occurrences edited individually are kept.
+func RescheduleMaintenanceSeries(tx *gorm.DB, series *models.MaintenanceSeries, now time.Time) error {
+	rule, err := ParseRecurrenceRule(series.RecurrenceRule)
+	if err != nil {
+		return err
+	}
+
+	var upcoming []models.Maintenance
+	if err := tx.Where("series_id = ? AND status = ? AND detached = ?", series.ID, models.MaintenanceScheduled, false).
+		Order("scheduled_start ASC").
+		Find(&upcoming).Error; err != nil {
+		return err
+	}
+
+	upcomingIDs := make([]string, 0, len(upcoming))
+	for _, maintenance := range upcoming {
+		upcomingIDs = append(upcomingIDs, maintenance.ID)
+	}
+
+	// Starts held by other occurrences, including deleted ones, cannot be reused
+	var takenStarts []time.Time
+	takenQuery := tx.Unscoped().Model(&models.Maintenance{}).Where("series_id = ?", series.ID)
+	if len(upcomingIDs) > 0 {
+		takenQuery = takenQuery.Where("id NOT IN ?", upcomingIDs)
+	}
+	if err := takenQuery.Pluck("occurrence_start", &takenStarts).Error; err != nil {
+		return err
+	}
+
+	taken := make(map[int64]bool, len(takenStarts))
+	for _, start := range takenStarts {
+		taken[start.Unix()] = true
+	}
+
+	var starts []time.Time
+	wanted := make(map[int64]bool)
+	for _, start := range rule.Between(series.StartsAt.UTC(), now, now.Add(MaintenanceSeriesHorizon)) {
+		if !taken[start.Unix()] {
+			starts = append(starts, start)
+			wanted[start.Unix()] = true
+		}
+	}
+
+	// Occurrences whose start is still part of the schedule keep it; the others take the
+	// remaining starts in order
+	targets := make(map[string]time.Time, len(upcoming))
+	kept := make(map[int64]bool)
+	for _, maintenance := range upcoming {
+		if maintenance.OccurrenceStart != nil && wanted[maintenance.OccurrenceStart.Unix()] && !kept[maintenance.OccurrenceStart.Unix()] {
+			targets[maintenance.ID] = *maintenance.OccurrenceStart
+			kept[maintenance.OccurrenceStart.Unix()] = true
+		}
+	}
+
+	var free []time.Time
+	for _, start := range starts {
+		if !kept[start.Unix()] {
+			free = append(free, start)
+		}
+	}
+	for _, maintenance := range upcoming {
+		if _, ok := targets[maintenance.ID]; ok || len(free) == 0 {
+			continue
+		}
+		targets[maintenance.ID] = free[0]
+		free = free[1:]
+	}
+
+	var serviceIDs []string
+	if err := tx.Model(&models.MaintenanceSeriesService{}).
+		Where("series_id = ? AND service_id IN (?)", series.ID, tx.Model(&models.Service{}).Select("id")).
+		Pluck("service_id", &serviceIDs).Error; err != nil {
+		return err
+	}
+
+	for i := range upcoming {
+		maintenance := &upcoming[i]
+
+		start, ok := targets[maintenance.ID]
+		if !ok {
+			// Deleted rather than removed, so calendar feeds can report the cancellation
+			if err := tx.Delete(maintenance).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		occurrenceStart := start
+		maintenance.Title = series.Title
+		maintenance.Description = series.Description
+		maintenance.ScheduledStart = start
+		maintenance.ScheduledEnd = start.Add(time.Duration(series.DurationMinutes) * time.Minute)
+		maintenance.OccurrenceStart = &occurrenceStart
+		maintenance.Sequence++
+
+		if err := tx.Save(maintenance).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("maintenance_id = ?", maintenance.ID).Delete(&models.MaintenanceService{}).Error; err != nil {
+			return err
+		}
+
+		for _, serviceID := range serviceIDs {
+			link := models.MaintenanceService{
+				MaintenanceID: maintenance.ID,
+				ServiceID:     serviceID,
+			}
+			if err := tx.Create(&link).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = ExpandMaintenanceSeries(tx, series, now)
+	return err
+}
+
+// MaintenanceScheduler expands recurring maintenances and moves maintenances through their
+// lifecycle at their scheduled times
+type MaintenanceScheduler struct {
+	db        *gorm.DB
+	websocket *WebSocketService
+	interval  time.Duration
+	stop      chan struct{}
+}
+
+// NewMaintenanceScheduler creates a scheduler that checks maintenances every interval
+func NewMaintenanceScheduler(db *gorm.DB, websocket *WebSocketService, interval time.Duration) *MaintenanceScheduler {
+	return &MaintenanceScheduler{
+		db:        db,
+		websocket: websocket,
+		interval:  interval,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler in the background until Stop is called
+func (s *MaintenanceScheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.Tick(time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				s.Tick(now)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the background scheduler
+func (s *MaintenanceScheduler) Stop() {
+	close(s.stop)
+}
+
+// Tick expands recurring series, starts maintenances whose window has opened and completes
+// those whose window has closed
+func (s *MaintenanceScheduler) Tick(now time.Time) {
+	var series []models.MaintenanceSeries
+	if err := s.db.Find(&series).Error; err != nil {
+		log.Printf("Failed to load maintenance series: %v", err)
+	}
+
+	for i := range series {
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			_, err := ExpandMaintenanceSeries(tx, &series[i], now)
+			return err
+		}); err != nil {
+			log.Printf("Failed to expand maintenance series %s: %v", series[i].ID, err)
+		}
+	}
+
+	var due []models.Maintenance
+	if err := s.db.Where("status = ? AND scheduled_start <= ?", models.MaintenanceScheduled, now).
+		Find(&due).Error; err != nil {
+		log.Printf("Failed to load due maintenances: %v", err)
+		return
+	}
+
+	for i := range due {
+		maintenance := &due[i]
+
+		// A window that already closed (e.g. while the server was down) is completed directly
+		if !maintenance.ScheduledEnd.After(now) {
+			s.transition(maintenance, MaintenanceCompleted, func(tx *gorm.DB) error {
+				return EndMaintenance(tx, maintenance, models.MaintenanceCompleted, now)
+			})
+			continue
+		}
+
+		s.transition(maintenance, MaintenanceStarted, func(tx *gorm.DB) error {
+			return StartMaintenance(tx, maintenance, now)
+		})
+	}
+
+	var finished []models.Maintenance
+	if err := s.db.Where("status = ? AND scheduled_end <= ?", models.MaintenanceInProgress, now).
+		Find(&finished).Error; err != nil {
+		log.Printf("Failed to load finished maintenances: %v", err)
+		return
+	}
+
+	for i := range finished {
+		maintenance := &finished[i]
+		s.transition(maintenance, MaintenanceCompleted, func(tx *gorm.DB) error {
+			return EndMaintenance(tx, maintenance, models.MaintenanceCompleted, now)
+		})
+	}
+}
+
+// transition applies a lifecycle change in a transaction and notifies connected clients. The
+// maintenance is locked and re-read first; if it was cancelled, completed, edited or deleted
+// since it was loaded, it is skipped and reconsidered on the next tick.
+func (s *MaintenanceScheduler) transition(maintenance *models.Maintenance, event string, apply func(tx *gorm.DB) error) {
+	changed := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Maintenance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", maintenance.ID).
+			First(&current).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				changed = true
+				return nil
+			}
+			return err
+		}
+
+		if current.Status != maintenance.Status || !current.UpdatedAt.Equal(maintenance.UpdatedAt) {
+			changed = true
+			return nil
+		}
+
+		return apply(tx)
+	})
+	if err != nil {
+		log.Printf("Failed to update maintenance %s: %v", maintenance.ID, err)
+		return
+	}
+
+	if changed {
+		return
+	}
+
+	if s.websocket != nil {
+		s.websocket.BroadcastToOrganization(maintenance.OrgID, event, maintenance)
+	}
+}
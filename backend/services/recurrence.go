@@ -0,0 +1,277 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported recurrence frequencies
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+)
+
+// maxRecurrencePeriods bounds expansion of rules that rarely or never produce an occurrence
+const maxRecurrencePeriods = 10000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RecurrenceDay is a BYDAY entry such as TU (every Tuesday) or 2TU (second Tuesday of the month)
+type RecurrenceDay struct {
+	Ordinal int // 0 for every matching weekday, negative to count from the end of the month
+	Weekday time.Weekday
+}
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE supported for maintenance series
+type RecurrenceRule struct {
+	Frequency  string
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+// ParseRecurrenceRule parses an RRULE such as "FREQ=MONTHLY;BYDAY=2TU;COUNT=12"
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	rule := RecurrenceRule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return rule, fmt.Errorf("recurrence rule is empty")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return rule, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(val)
+			if rule.Frequency != FrequencyDaily && rule.Frequency != FrequencyWeekly && rule.Frequency != FrequencyMonthly {
+				return rule, fmt.Errorf("unsupported frequency %q", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return rule, fmt.Errorf("invalid interval %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return rule, fmt.Errorf("invalid count %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceTime(val)
+			if err != nil {
+				return rule, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := parseRecurrenceDay(code)
+				if err != nil {
+					return rule, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(code)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return rule, fmt.Errorf("invalid month day %q", code)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		default:
+			return rule, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return rule, fmt.Errorf("recurrence rule requires FREQ")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return rule, fmt.Errorf("recurrence rule cannot combine COUNT and UNTIL")
+	}
+	if rule.Frequency == FrequencyDaily && (len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0) {
+		return rule, fmt.Errorf("BYDAY and BYMONTHDAY are not supported with DAILY frequency")
+	}
+	if rule.Frequency == FrequencyWeekly && len(rule.ByMonthDay) > 0 {
+		return rule, fmt.Errorf("BYMONTHDAY is not supported with WEEKLY frequency")
+	}
+	if rule.Frequency != FrequencyMonthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return rule, fmt.Errorf("ordinal BYDAY values are only supported with MONTHLY frequency")
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+// parseRecurrenceDay parses a BYDAY value such as "TU", "2TU" or "-1FR"
+func parseRecurrenceDay(code string) (RecurrenceDay, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("invalid weekday %q", code)
+	}
+
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("invalid weekday %q", code)
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, fmt.Errorf("invalid weekday %q", code)
+		}
+		day.Ordinal = ordinal
+	}
+
+	return day, nil
+}
+
+// parseRecurrenceTime parses an UNTIL value in either date-time or date form
+func parseRecurrenceTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid until %q", value)
+}
+
+// Between returns the occurrences of the rule starting at start that fall within [from, to).
+// COUNT is always counted from start, regardless of the requested window.
+func (r RecurrenceRule) Between(start, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	produced := 0
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(start, period)
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return occurrences
+			}
+			if !candidate.Before(to) {
+				return occurrences
+			}
+
+			produced++
+			if !candidate.Before(from) {
+				occurrences = append(occurrences, candidate)
+			}
+			if r.Count > 0 && produced >= r.Count {
+				return occurrences
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// periodCandidates returns the sorted occurrence candidates of the nth period after start
+func (r RecurrenceRule) periodCandidates(start time.Time, period int) []time.Time {
+	hour, minute, second := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, start.Location())
+	}
+
+	var candidates []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		candidates = append(candidates, start.AddDate(0, 0, period*r.Interval))
+
+	case FrequencyWeekly:
+		// Weeks start on Monday, as with the RFC 5545 default WKST
+		offset := (int(start.Weekday()) + 6) % 7
+		weekStart := at(start.Year(), start.Month(), start.Day()-offset).AddDate(0, 0, period*7*r.Interval)
+
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []RecurrenceDay{{Weekday: start.Weekday()}}
+		}
+		for _, day := range days {
+			candidates = append(candidates, weekStart.AddDate(0, 0, (int(day.Weekday)+6)%7))
+		}
+
+	case FrequencyMonthly:
+		first := at(start.Year(), start.Month(), 1).AddDate(0, period*r.Interval, 0)
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		monthDays := make(map[int]bool, len(r.ByMonthDay))
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = daysInMonth + d + 1
+			}
+			if d >= 1 && d <= daysInMonth {
+				monthDays[d] = true
+			}
+		}
+
+		switch {
+		case len(r.ByDay) > 0:
+			// Combined with BYMONTHDAY, a day must match both, e.g. BYDAY=FR;BYMONTHDAY=13
+			add := func(d int) {
+				if len(r.ByMonthDay) == 0 || monthDays[d] {
+					candidates = append(candidates, at(first.Year(), first.Month(), d))
+				}
+			}
+
+			for _, day := range r.ByDay {
+				var matches []int
+				for d := 1; d <= daysInMonth; d++ {
+					if at(first.Year(), first.Month(), d).Weekday() == day.Weekday {
+						matches = append(matches, d)
+					}
+				}
+
+				switch {
+				case day.Ordinal == 0:
+					for _, d := range matches {
+						add(d)
+					}
+				case day.Ordinal > 0 && day.Ordinal <= len(matches):
+					add(matches[day.Ordinal-1])
+				case day.Ordinal < 0 && -day.Ordinal <= len(matches):
+					add(matches[len(matches)+day.Ordinal])
+				}
+			}
+		case len(r.ByMonthDay) > 0:
+			for d := range monthDays {
+				candidates = append(candidates, at(first.Year(), first.Month(), d))
+			}
+		default:
+			// Months without the start's day of month are skipped, as RFC 5545 requires
+			if start.Day() <= daysInMonth {
+				candidates = append(candidates, at(first.Year(), first.Month(), start.Day()))
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return candidates
+}
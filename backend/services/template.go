@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholderPattern matches template placeholders such as {{service}} or {{ eta }}
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplatePlaceholders returns the distinct variable names referenced by a template, sorted
+func TemplatePlaceholders(text string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateTemplate reports malformed placeholders, i.e. braces that do not form a {{name}} placeholder
+func ValidateTemplate(text string) error {
+	stripped := placeholderPattern.ReplaceAllString(text, "")
+	if strings.Contains(stripped, "{{") || strings.Contains(stripped, "}}") {
+		return fmt.Errorf("malformed placeholder in %q", text)
+	}
+	return nil
+}
+
+// RenderTemplate substitutes every placeholder with its variable, failing if any is missing
+func RenderTemplate(text string, variables map[string]string) (string, error) {
+	if err := ValidateTemplate(text); err != nil {
+		return "", err
+	}
+
+	var missing []string
+	for _, name := range TemplatePlaceholders(text) {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return variables[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	}), nil
+}
@@ -85,6 +85,11 @@ const (
 	IncidentCreated = "INCIDENT_CREATED"
 	IncidentUpdated = "INCIDENT_UPDATED"
 	UpdateAdded     = "UPDATE_ADDED"
+
+	MaintenanceStarted   = "MAINTENANCE_STARTED"
+	MaintenanceCompleted = "MAINTENANCE_COMPLETED"
+
+	PostmortemPublished = "POSTMORTEM_PUBLISHED"
 )
 
 // --->>here<<--- WebSocket service for real-time updates
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+
 	"github.com/google/uuid"
 )
 
@@ -8,3 +11,27 @@ import (
 func GenerateUUID() string {
 	return uuid.New().String()
 }
+
+// slugAlphabet leaves out characters that are easily confused, such as 0/o and 1/l
+const slugAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// GenerateSlug generates a short random identifier suitable for sharing in links
+func GenerateSlug() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = slugAlphabet[int(b[i])%len(slugAlphabet)]
+	}
+	return string(b)
+}
+
+// GenerateToken generates a random hex-encoded secret of 16 bytes
+func GenerateToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}